@@ -0,0 +1,120 @@
+// Package auth authorizes the HTTP requests of the client to remote MCP servers, following
+// the MCP authorization flow (OAuth 2.1 with PKCE, authorization server metadata discovery and
+// dynamic client registration).
+package auth
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+)
+
+// Token is an OAuth access token with its refresh token and expiry.
+type Token = transport.Token
+
+// TokenStore stores the token of a server. Implementations must return ErrNoToken when no
+// token is stored.
+type TokenStore = transport.TokenStore
+
+// ErrNoToken is returned by a TokenStore that holds no token.
+var ErrNoToken = transport.ErrNoToken
+
+// NewMemoryTokenStore returns a TokenStore that keeps the token in memory.
+func NewMemoryTokenStore() TokenStore {
+	return transport.NewMemoryTokenStore()
+}
+
+// Provider supplies the access tokens that authorize requests to an MCP server.
+type Provider interface {
+	// Token returns the token to authorize requests with, obtaining one if needed.
+	Token(ctx context.Context) (*Token, error)
+	// Refresh returns a new token after the server rejected rejected with 401 Unauthorized.
+	Refresh(ctx context.Context, rejected *Token) (*Token, error)
+}
+
+// Transport is an http.RoundTripper that authorizes requests with the token of Provider.
+// When the server responds with 401 Unauthorized, it refreshes the token and retries the
+// request once.
+type Transport struct {
+	Provider Provider
+	Base     http.RoundTripper // Defaults to http.DefaultTransport
+}
+
+var _ http.RoundTripper = (*Transport)(nil)
+
+// NewHTTPClient returns a copy of base, or of an empty http.Client if base is nil, whose
+// requests are authorized by provider.
+func NewHTTPClient(provider Provider, base *http.Client) *http.Client {
+	httpClient := &http.Client{}
+	if base != nil {
+		*httpClient = *base
+	}
+	httpClient.Transport = &Transport{Provider: provider, Base: httpClient.Transport}
+	return httpClient
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	token, err := t.Provider.Token(ctx)
+	if err != nil {
+		closeBody(req)
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	resp, err := t.base().RoundTrip(authorize(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	// A request whose body cannot be sent again is answered with the 401
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+	drain(resp)
+
+	token, err = t.Provider.Refresh(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh access token: %w", err)
+	}
+	retry := authorize(req, token)
+	if req.Body != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+	}
+	return t.base().RoundTrip(retry)
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// authorize returns a copy of req with the Authorization header set to token.
+func authorize(req *http.Request, token *Token) *http.Request {
+	authorized := req.Clone(req.Context())
+	tokenType := token.TokenType
+	if tokenType == "" || tokenType == "bearer" {
+		tokenType = "Bearer"
+	}
+	authorized.Header.Set("Authorization", tokenType+" "+token.AccessToken)
+	return authorized
+}
+
+// drain reads and closes the body of resp so that its connection can be reused.
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	_ = resp.Body.Close()
+}
+
+// closeBody closes the body of a request that is not sent, as RoundTrip must.
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
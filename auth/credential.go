@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credential resolves a secret, such as an API key, when it is needed, so that it does not
+// have to be stored in the connection config. Implementations must not include the secret
+// in errors.
+type Credential interface {
+	Resolve(ctx context.Context) (string, error)
+}
+
+// Refresher is implemented by credentials that cache their secret. Refresh resolves the
+// secret again, for example after the server rejected it.
+type Refresher interface {
+	Refresh(ctx context.Context) (string, error)
+}
+
+// CredentialFunc adapts a function to a Credential, for example to read a secret manager.
+type CredentialFunc func(ctx context.Context) (string, error)
+
+// Resolve calls f.
+func (f CredentialFunc) Resolve(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// EnvCredential returns a Credential that reads the environment variable name. It fails if
+// the variable is not set.
+func EnvCredential(name string) Credential {
+	return CredentialFunc(func(context.Context) (string, error) {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return value, nil
+	})
+}
+
+// FileCredential returns a Credential that reads the file at path, such as a mounted
+// Kubernetes secret, without trailing whitespace.
+func FileCredential(path string) Credential {
+	return CredentialFunc(func(context.Context) (string, error) {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read credential file: %w", err)
+		}
+		return strings.TrimRight(string(raw), " \t\r\n"), nil
+	})
+}
+
+// CommandCredential returns a Credential that runs a command and uses its standard output
+// without trailing whitespace, for example `gcloud auth print-access-token`. The command runs
+// on every resolution, so wrap it with CachedCredential if it is slow.
+func CommandCredential(name string, args ...string) Credential {
+	return CredentialFunc(func(ctx context.Context) (string, error) {
+		var stdout bytes.Buffer
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Stdout = &stdout
+		// Standard error is discarded, as it might echo the secret
+		if err := cmd.Run(); err != nil {
+			return "", fmt.Errorf("credential command %s failed: %w", name, err)
+		}
+		return strings.TrimRight(stdout.String(), " \t\r\n"), nil
+	})
+}
+
+// CachedCredential caches the secret of a Credential for ttl, for short-lived tokens that are
+// expensive to obtain. It implements Refresher.
+func CachedCredential(credential Credential, ttl time.Duration) Credential {
+	return &cachedCredential{credential: credential, ttl: ttl}
+}
+
+type cachedCredential struct {
+	credential Credential
+	ttl        time.Duration
+
+	mu      sync.Mutex
+	value   string
+	expires time.Time
+}
+
+// Resolve returns the cached secret, resolving it again once it expired.
+func (c *cachedCredential) Resolve(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.expires.IsZero() && time.Now().Before(c.expires) {
+		return c.value, nil
+	}
+	return c.resolve(ctx)
+}
+
+// Refresh resolves the secret again, even if it did not expire.
+func (c *cachedCredential) Refresh(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.resolve(ctx)
+}
+
+func (c *cachedCredential) resolve(ctx context.Context) (string, error) {
+	value, err := c.credential.Resolve(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.value = value
+	c.expires = time.Now().Add(c.ttl)
+	return value, nil
+}
+
+// BearerProvider returns a Provider that authorizes requests with the secret of credential as
+// a bearer token. When the server rejects the token, the credential is refreshed if it is a
+// Refresher and resolved again otherwise.
+func BearerProvider(credential Credential) Provider {
+	return bearerProvider{credential: credential}
+}
+
+type bearerProvider struct {
+	credential Credential
+}
+
+func (p bearerProvider) Token(ctx context.Context) (*Token, error) {
+	return bearerToken(p.credential.Resolve(ctx))
+}
+
+func (p bearerProvider) Refresh(ctx context.Context, _ *Token) (*Token, error) {
+	if refresher, ok := p.credential.(Refresher); ok {
+		return bearerToken(refresher.Refresh(ctx))
+	}
+	return p.Token(ctx)
+}
+
+func bearerToken(value string, err error) (*Token, error) {
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		return nil, errors.New("credential is empty")
+	}
+	return &Token{AccessToken: value, TokenType: "Bearer"}, nil
+}
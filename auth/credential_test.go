@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentials(t *testing.T) {
+	t.Setenv("MCP_TEST_API_KEY", "from-env")
+	path := filepath.Join(t.TempDir(), "api-key")
+	require.NoError(t, os.WriteFile(path, []byte("from-file\n"), 0o600))
+
+	tests := []struct {
+		name       string
+		credential Credential
+		expected   string
+		errMessage string
+	}{
+		{"env", EnvCredential("MCP_TEST_API_KEY"), "from-env", ""},
+		{"env not set", EnvCredential("MCP_TEST_UNSET"), "", "environment variable MCP_TEST_UNSET is not set"},
+		{"file", FileCredential(path), "from-file", ""},
+		{"file missing", FileCredential(path + ".missing"), "", "failed to read credential file"},
+		{"command", CommandCredential("echo", "from-command"), "from-command", ""},
+		{"command fails", CommandCredential("false"), "", "credential command false failed"},
+		{"func", CredentialFunc(func(context.Context) (string, error) { return "from-func", nil }), "from-func", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := tt.credential.Resolve(context.Background())
+			if tt.errMessage != "" {
+				require.ErrorContains(t, err, tt.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+// countingCredential returns a new secret on every resolution.
+type countingCredential struct{ count int }
+
+func (c *countingCredential) Resolve(context.Context) (string, error) {
+	c.count++
+	return "token-" + strconv.Itoa(c.count), nil
+}
+
+func TestCachedCredential(t *testing.T) {
+	source := &countingCredential{}
+	credential := CachedCredential(source, time.Hour)
+
+	first, err := credential.Resolve(context.Background())
+	require.NoError(t, err)
+	second, err := credential.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", first)
+	assert.Equal(t, first, second, "The secret should be cached until it expires")
+
+	refreshed, err := credential.(Refresher).Refresh(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", refreshed)
+	assert.Equal(t, 2, source.count)
+
+	expiring := CachedCredential(source, 0)
+	_, _ = expiring.Resolve(context.Background())
+	_, _ = expiring.Resolve(context.Background())
+	assert.Equal(t, 4, source.count, "A secret with no TTL should be resolved every time")
+}
+
+func TestBearerProvider_RefreshOnUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	source := &countingCredential{}
+	httpClient := NewHTTPClient(BearerProvider(CachedCredential(source, time.Hour)), nil)
+
+	resp, err := httpClient.Get(server.URL)
+
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, source.count)
+}
+
+func TestBearerProvider_Errors(t *testing.T) {
+	_, err := BearerProvider(CredentialFunc(func(context.Context) (string, error) { return "", nil })).Token(context.Background())
+	require.ErrorContains(t, err, "credential is empty")
+
+	failure := errors.New("secret manager unavailable")
+	_, err = BearerProvider(CredentialFunc(func(context.Context) (string, error) { return "", failure })).Token(context.Background())
+	require.ErrorIs(t, err, failure)
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// LoopbackAuthorizer returns an Authorizer for command line tools and desktop applications.
+// It listens on the host and port of redirectURI, passes the authorization URL to open, for
+// example to open it in a browser or print it, and waits for the redirect from the
+// authorization server.
+func LoopbackAuthorizer(redirectURI string, open func(authorizationURL string) error) Authorizer {
+	return func(ctx context.Context, authorizationURL string) (AuthorizationResponse, error) {
+		redirect, err := url.Parse(redirectURI)
+		if err != nil {
+			return AuthorizationResponse{}, fmt.Errorf("invalid redirect URI: %w", err)
+		}
+		listener, err := net.Listen("tcp", redirect.Host)
+		if err != nil {
+			return AuthorizationResponse{}, fmt.Errorf("failed to listen for redirect: %w", err)
+		}
+
+		type result struct {
+			response AuthorizationResponse
+			err      error
+		}
+		results := make(chan result, 1)
+		path := redirect.Path
+		if path == "" {
+			path = "/"
+		}
+		mux := http.NewServeMux()
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			var res result
+			if code := query.Get("error"); code != "" {
+				res.err = fmt.Errorf("authorization server returned %s: %s", code, query.Get("error_description"))
+				http.Error(w, "Authorization failed. You can close this window.", http.StatusBadRequest)
+			} else {
+				res.response = AuthorizationResponse{Code: query.Get("code"), State: query.Get("state")}
+				_, _ = fmt.Fprintln(w, "Authorization complete. You can close this window.")
+			}
+			select {
+			case results <- res:
+			default: // Only the first redirect counts
+			}
+		})
+		server := &http.Server{Handler: mux}
+		go func() { _ = server.Serve(listener) }()
+		defer server.Close()
+
+		if err := open(authorizationURL); err != nil {
+			return AuthorizationResponse{}, fmt.Errorf("failed to open authorization URL: %w", err)
+		}
+		select {
+		case res := <-results:
+			if res.err == nil && res.response.Code == "" {
+				res.err = errors.New("redirect has no authorization code")
+			}
+			return res.response, res.err
+		case <-ctx.Done():
+			return AuthorizationResponse{}, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+)
+
+// DefaultClientName is the name used for dynamic client registration.
+const DefaultClientName = "langchaingo-mcp-client"
+
+// expiryMargin is how long before its expiry a token is refreshed, so that it does not
+// expire in flight.
+const expiryMargin = 30 * time.Second
+
+// ErrAuthorizationRequired is returned when there is no usable token and no Authorizer to
+// obtain one interactively.
+var ErrAuthorizationRequired = errors.New("authorization required")
+
+// AuthorizationResponse holds the parameters of the redirect to the redirect URI at the end
+// of the interactive authorization step.
+type AuthorizationResponse struct {
+	Code  string
+	State string
+}
+
+// Authorizer performs the interactive step of the authorization code flow: it sends the user
+// to authorizationURL, for example by opening a browser, and returns the parameters of the
+// redirect to the redirect URI. See LoopbackAuthorizer.
+type Authorizer func(ctx context.Context, authorizationURL string) (AuthorizationResponse, error)
+
+// OAuthConfig configures an OAuthProvider.
+type OAuthConfig struct {
+	ClientID              string       // Registered dynamically if empty
+	ClientSecret          string       // Empty for public clients
+	ClientName            string       // Name for dynamic client registration, defaults to DefaultClientName
+	RedirectURI           string       // Must be a localhost or HTTPS URL
+	Scopes                []string     // Scopes to request
+	TokenStore            TokenStore   // Defaults to an in-memory store
+	AuthServerMetadataURL string       // Discovered from the server URL if empty
+	HTTPClient            *http.Client // Client for the authorization server, defaults to one with a 30 second timeout
+	Authorizer            Authorizer   // Interactive step, without it only stored tokens are used
+}
+
+// OAuthProvider is a Provider that obtains tokens with the OAuth 2.1 authorization code flow
+// with PKCE. It discovers the authorization server from the MCP server URL, registers a client
+// if no ClientID is configured, and refreshes tokens when they expire or are rejected.
+type OAuthProvider struct {
+	config  OAuthConfig
+	store   TokenStore
+	handler *transport.OAuthHandler
+
+	mu sync.Mutex // Serializes the authorization and refresh of tokens
+}
+
+var _ Provider = (*OAuthProvider)(nil)
+
+// NewOAuthProvider creates an OAuthProvider for the MCP server at serverURL.
+func NewOAuthProvider(serverURL string, config OAuthConfig) (*OAuthProvider, error) {
+	parsed, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server URL: %w", err)
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid server URL: missing scheme or host in %q", serverURL)
+	}
+	if err := transport.ValidateRedirectURI(config.RedirectURI); err != nil {
+		return nil, err
+	}
+	if config.ClientName == "" {
+		config.ClientName = DefaultClientName
+	}
+	if config.TokenStore == nil {
+		config.TokenStore = NewMemoryTokenStore()
+	}
+
+	handler := transport.NewOAuthHandler(transport.OAuthConfig{
+		ClientID:              config.ClientID,
+		ClientSecret:          config.ClientSecret,
+		RedirectURI:           config.RedirectURI,
+		Scopes:                config.Scopes,
+		TokenStore:            config.TokenStore,
+		AuthServerMetadataURL: config.AuthServerMetadataURL,
+		PKCEEnabled:           true,
+		HTTPClient:            config.HTTPClient,
+	})
+	handler.SetBaseURL(parsed.Scheme + "://" + parsed.Host)
+
+	return &OAuthProvider{config: config, store: config.TokenStore, handler: handler}, nil
+}
+
+// Token returns the stored token, refreshing it if it expired, or runs the authorization
+// flow if there is no usable token.
+func (p *OAuthProvider) Token(ctx context.Context) (*Token, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	token, err := p.storedToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if usable(token) {
+		return token, nil
+	}
+	return p.renew(ctx, token)
+}
+
+// Refresh returns a new token after rejected was rejected by the server. If another request
+// already replaced rejected, the replacement is returned.
+func (p *OAuthProvider) Refresh(ctx context.Context, rejected *Token) (*Token, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	token, err := p.storedToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if usable(token) && (rejected == nil || token.AccessToken != rejected.AccessToken) {
+		return token, nil
+	}
+	return p.renew(ctx, token)
+}
+
+// storedToken returns the token of the store, or nil if there is none.
+func (p *OAuthProvider) storedToken(ctx context.Context) (*Token, error) {
+	token, err := p.store.GetToken(ctx)
+	if errors.Is(err, ErrNoToken) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load token: %w", err)
+	}
+	return token, nil
+}
+
+// renew refreshes token if it has a refresh token, and runs the authorization flow otherwise
+// or if the refresh fails.
+func (p *OAuthProvider) renew(ctx context.Context, token *Token) (*Token, error) {
+	if token != nil && token.RefreshToken != "" && p.handler.GetClientID() != "" {
+		refreshed, err := p.handler.RefreshToken(ctx, token.RefreshToken)
+		if err == nil {
+			return refreshed, nil
+		}
+		if p.config.Authorizer == nil {
+			return nil, fmt.Errorf("failed to refresh token: %w", err)
+		}
+	}
+	return p.authorize(ctx)
+}
+
+// authorize runs the authorization code flow with PKCE, registering a client first if needed.
+func (p *OAuthProvider) authorize(ctx context.Context) (*Token, error) {
+	if p.config.Authorizer == nil {
+		return nil, ErrAuthorizationRequired
+	}
+
+	if p.handler.GetClientID() == "" {
+		if err := p.handler.RegisterClient(ctx, p.config.ClientName); err != nil {
+			return nil, fmt.Errorf("failed to register client: %w", err)
+		}
+	}
+
+	verifier, err := transport.GenerateCodeVerifier()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate code verifier: %w", err)
+	}
+	state, err := transport.GenerateState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate state: %w", err)
+	}
+	authorizationURL, err := p.handler.GetAuthorizationURL(ctx, state, transport.GenerateCodeChallenge(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build authorization URL: %w", err)
+	}
+
+	response, err := p.config.Authorizer(ctx, authorizationURL)
+	if err != nil {
+		return nil, fmt.Errorf("authorization failed: %w", err)
+	}
+	if err := p.handler.ProcessAuthorizationResponse(ctx, response.Code, response.State, verifier); err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	token, err := p.store.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load token: %w", err)
+	}
+	return token, nil
+}
+
+// usable reports whether token can authorize requests for at least expiryMargin.
+func usable(token *Token) bool {
+	if token == nil || token.AccessToken == "" {
+		return false
+	}
+	return token.ExpiresAt.IsZero() || time.Until(token.ExpiresAt) > expiryMargin
+}
@@ -0,0 +1,324 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// authServer is a stand-in for an MCP server that is its own OAuth authorization server.
+type authServer struct {
+	*httptest.Server
+
+	mu             sync.Mutex
+	clients        map[string]bool   // Registered client IDs
+	challenges     map[string]string // Code challenges by authorization code
+	validTokens    map[string]bool   // Access tokens accepted by /mcp
+	refreshTokens  map[string]bool
+	issued         int
+	registrations  int
+	authorizations int
+	refreshes      int
+	bodies         []string // Bodies of the requests accepted by /mcp
+}
+
+func newAuthServer(t *testing.T) *authServer {
+	s := &authServer{
+		clients:       make(map[string]bool),
+		challenges:    make(map[string]string),
+		validTokens:   make(map[string]bool),
+		refreshTokens: make(map[string]bool),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/oauth-authorization-server", s.metadata)
+	mux.HandleFunc("/register", s.register)
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+	mux.HandleFunc("/mcp", s.mcp)
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *authServer) metadata(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"issuer":                           s.URL,
+		"authorization_endpoint":           s.URL + "/authorize",
+		"token_endpoint":                   s.URL + "/token",
+		"registration_endpoint":            s.URL + "/register",
+		"code_challenge_methods_supported": []string{"S256"},
+	})
+}
+
+func (s *authServer) register(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		RedirectURIs []string `json:"redirect_uris"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || len(request.RedirectURIs) == 0 {
+		http.Error(w, `{"error": "invalid_client_metadata"}`, http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	s.registrations++
+	clientID := fmt.Sprintf("client-%d", s.registrations)
+	s.clients[clientID] = true
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(map[string]any{"client_id": clientID})
+}
+
+// authorize approves the request without user interaction and redirects with a code.
+func (s *authServer) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.clients[query.Get("client_id")] || query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	s.authorizations++
+	code := fmt.Sprintf("code-%d", s.authorizations)
+	s.challenges[code] = query.Get("code_challenge")
+
+	redirect, _ := url.Parse(query.Get("redirect_uri"))
+	redirect.RawQuery = url.Values{"code": {code}, "state": {query.Get("state")}}.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (s *authServer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, `{"error": "invalid_request"}`, http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Form.Get("grant_type") {
+	case "authorization_code":
+		challenge, ok := s.challenges[r.Form.Get("code")]
+		delete(s.challenges, r.Form.Get("code"))
+		hash := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+		if !ok || base64.RawURLEncoding.EncodeToString(hash[:]) != challenge {
+			http.Error(w, `{"error": "invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+	case "refresh_token":
+		if !s.refreshTokens[r.Form.Get("refresh_token")] {
+			http.Error(w, `{"error": "invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		delete(s.refreshTokens, r.Form.Get("refresh_token"))
+		s.refreshes++
+	default:
+		http.Error(w, `{"error": "unsupported_grant_type"}`, http.StatusBadRequest)
+		return
+	}
+
+	s.issued++
+	accessToken := fmt.Sprintf("access-%d", s.issued)
+	refreshToken := fmt.Sprintf("refresh-%d", s.issued)
+	s.validTokens[accessToken] = true
+	s.refreshTokens[refreshToken] = true
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token":  accessToken,
+		"token_type":    "bearer",
+		"refresh_token": refreshToken,
+		"expires_in":    3600,
+	})
+}
+
+// mcp stands in for the protected MCP endpoint.
+func (s *authServer) mcp(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.validTokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")] {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	s.bodies = append(s.bodies, string(body))
+	_, _ = fmt.Fprint(w, "ok")
+}
+
+// revoke makes /mcp reject all access tokens issued so far.
+func (s *authServer) revoke() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.validTokens)
+}
+
+// followRedirect is an Authorizer that visits the authorization URL like a browser whose user
+// approves, and reads the parameters of the redirect.
+func followRedirect(_ context.Context, authorizationURL string) (AuthorizationResponse, error) {
+	noRedirect := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := noRedirect.Get(authorizationURL)
+	if err != nil {
+		return AuthorizationResponse{}, err
+	}
+	defer resp.Body.Close()
+	location, err := resp.Location()
+	if err != nil {
+		return AuthorizationResponse{}, fmt.Errorf("authorization was not redirected: %s", resp.Status)
+	}
+	return AuthorizationResponse{Code: location.Query().Get("code"), State: location.Query().Get("state")}, nil
+}
+
+func TestOAuthProvider_AuthorizationCodeFlow(t *testing.T) {
+	server := newAuthServer(t)
+	store := NewMemoryTokenStore()
+	provider, err := NewOAuthProvider(server.URL+"/mcp", OAuthConfig{
+		RedirectURI: "http://localhost:8085/callback",
+		Scopes:      []string{"mcp"},
+		TokenStore:  store,
+		Authorizer:  followRedirect,
+	})
+	require.NoError(t, err)
+
+	token, err := provider.Token(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "access-1", token.AccessToken)
+	assert.Equal(t, "refresh-1", token.RefreshToken)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), token.ExpiresAt, time.Minute)
+	assert.Equal(t, 1, server.registrations)
+	assert.Equal(t, 1, server.authorizations)
+
+	stored, err := store.GetToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, token, stored)
+
+	// A stored token is reused without another authorization
+	token, err = provider.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "access-1", token.AccessToken)
+	assert.Equal(t, 1, server.authorizations)
+}
+
+func TestOAuthProvider_RefreshesExpiredToken(t *testing.T) {
+	server := newAuthServer(t)
+	store := NewMemoryTokenStore()
+	provider, err := NewOAuthProvider(server.URL, OAuthConfig{
+		RedirectURI: "http://localhost:8085/callback",
+		TokenStore:  store,
+		Authorizer:  followRedirect,
+	})
+	require.NoError(t, err)
+	token, err := provider.Token(context.Background())
+	require.NoError(t, err)
+
+	expired := *token
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	require.NoError(t, store.SaveToken(context.Background(), &expired))
+
+	token, err = provider.Token(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "access-2", token.AccessToken)
+	assert.Equal(t, 1, server.refreshes)
+	assert.Equal(t, 1, server.authorizations)
+}
+
+func TestOAuthProvider_AuthorizationRequired(t *testing.T) {
+	server := newAuthServer(t)
+	provider, err := NewOAuthProvider(server.URL, OAuthConfig{RedirectURI: "http://localhost:8085/callback"})
+	require.NoError(t, err)
+
+	_, err = provider.Token(context.Background())
+
+	require.ErrorIs(t, err, ErrAuthorizationRequired)
+	assert.Equal(t, 0, server.registrations)
+}
+
+func TestNewOAuthProvider_InvalidConfig(t *testing.T) {
+	_, err := NewOAuthProvider("/mcp", OAuthConfig{RedirectURI: "http://localhost:8085/callback"})
+	require.ErrorContains(t, err, "missing scheme or host")
+
+	_, err = NewOAuthProvider("https://mcp.example.com", OAuthConfig{RedirectURI: "http://example.com/callback"})
+	require.ErrorContains(t, err, "localhost")
+}
+
+func TestTransport_RefreshOnUnauthorized(t *testing.T) {
+	server := newAuthServer(t)
+	provider, err := NewOAuthProvider(server.URL+"/mcp", OAuthConfig{
+		RedirectURI: "http://localhost:8085/callback",
+		Authorizer:  followRedirect,
+	})
+	require.NoError(t, err)
+	httpClient := NewHTTPClient(provider, nil)
+
+	resp, err := httpClient.Post(server.URL+"/mcp", "application/json", strings.NewReader(`{"id": 1}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	server.revoke()
+	resp, err = httpClient.Post(server.URL+"/mcp", "application/json", strings.NewReader(`{"id": 2}`))
+
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 1, server.refreshes)
+	assert.Equal(t, 1, server.authorizations)
+	assert.Equal(t, []string{`{"id": 1}`, `{"id": 2}`}, server.bodies, "The retried request should be sent with its body")
+}
+
+// staticProvider always returns the same token and fails to refresh it.
+type staticProvider struct{ token Token }
+
+func (p staticProvider) Token(context.Context) (*Token, error) { return &p.token, nil }
+func (p staticProvider) Refresh(context.Context, *Token) (*Token, error) {
+	return nil, errors.New("cannot refresh")
+}
+
+func TestTransport_RefreshFails(t *testing.T) {
+	server := newAuthServer(t)
+	httpClient := NewHTTPClient(staticProvider{Token{AccessToken: "unknown"}}, nil)
+
+	_, err := httpClient.Get(server.URL + "/mcp")
+
+	require.ErrorContains(t, err, "failed to refresh access token: cannot refresh")
+}
+
+func TestLoopbackAuthorizer(t *testing.T) {
+	server := newAuthServer(t)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	redirectURI := "http://" + listener.Addr().String() + "/callback"
+	require.NoError(t, listener.Close())
+
+	provider, err := NewOAuthProvider(server.URL, OAuthConfig{
+		RedirectURI: redirectURI,
+		Authorizer: LoopbackAuthorizer(redirectURI, func(authorizationURL string) error {
+			// Stands in for the browser, which follows the redirect to the loopback server
+			go func() {
+				if resp, err := http.Get(authorizationURL); err == nil {
+					resp.Body.Close()
+				}
+			}()
+			return nil
+		}),
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	token, err := provider.Token(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, "access-1", token.AccessToken)
+}
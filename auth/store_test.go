@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileTokenStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens", "server.json")
+	store := NewFileTokenStore(path)
+
+	_, err := store.GetToken(context.Background())
+	require.ErrorIs(t, err, ErrNoToken)
+
+	token := &Token{
+		AccessToken:  "access",
+		TokenType:    "Bearer",
+		RefreshToken: "refresh",
+		ExpiresAt:    time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	require.NoError(t, store.SaveToken(context.Background(), token))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	loaded, err := NewFileTokenStore(path).GetToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, token, loaded)
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/auth"
+)
+
+// tokenProvider is an auth.Provider with a fixed token.
+type tokenProvider struct{ accessToken string }
+
+func (p tokenProvider) Token(context.Context) (*auth.Token, error) {
+	return &auth.Token{AccessToken: p.accessToken}, nil
+}
+
+func (p tokenProvider) Refresh(ctx context.Context, _ *auth.Token) (*auth.Token, error) {
+	return p.Token(ctx)
+}
+
+func TestMultiServerMCPClient_ConnectViaSSE_Auth(t *testing.T) {
+	sseServer := server.NewSSEServer(server.NewMCPServer("protected", "1.0.0"))
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		sseServer.ServeHTTP(w, r)
+	}))
+	defer httpServer.Close()
+
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{})
+
+	_, err := msc.connectToServerViaSSE(context.Background(), "protected", SSEConnection{URL: httpServer.URL + "/sse"})
+	require.Error(t, err, "Connecting without credentials should be rejected")
+
+	session, err := msc.connectToServerViaSSE(context.Background(), "protected", SSEConnection{
+		URL:  httpServer.URL + "/sse",
+		Auth: tokenProvider{accessToken: "secret"},
+	})
+	require.NoError(t, err)
+	assert.NoError(t, session.Close())
+}
+
+// interactiveProvider stands in for an OAuth provider whose first token requires the user to
+// log in, which takes longer than the connection timeout.
+type interactiveProvider struct {
+	tokenProvider
+	login time.Duration
+
+	mu       sync.Mutex
+	loggedIn bool
+}
+
+func (p *interactiveProvider) Token(ctx context.Context) (*auth.Token, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.loggedIn {
+		select {
+		case <-time.After(p.login):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		p.loggedIn = true
+	}
+	return p.tokenProvider.Token(ctx)
+}
+
+func TestMultiServerMCPClient_ConnectViaSSE_AuthOutlivesTimeout(t *testing.T) {
+	sseServer := server.NewSSEServer(server.NewMCPServer("protected", "1.0.0"))
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		sseServer.ServeHTTP(w, r)
+	}))
+	defer httpServer.Close()
+
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{})
+	session, err := msc.connectToServerViaSSE(context.Background(), "protected", SSEConnection{
+		URL:     httpServer.URL + "/sse",
+		Timeout: 50 * time.Millisecond,
+		Auth:    &interactiveProvider{tokenProvider: tokenProvider{accessToken: "secret"}, login: 200 * time.Millisecond},
+	})
+
+	require.NoError(t, err, "Logging in should not count against the connection timeout")
+	assert.NoError(t, session.Close())
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/tools"
+
+	lcgomcp "github.com/akihiro-fukuchi/langchaingo-mcp-adapters/prompt"
+	lcgomcptool "github.com/akihiro-fukuchi/langchaingo-mcp-adapters/tool"
+)
+
+// methodNotificationToolsListChanged is the JSON-RPC method used by servers to announce
+// that their list of tools changed.
+const methodNotificationToolsListChanged = "notifications/tools/list_changed"
+
+// CallbacksHandler extends callbacks.Handler with the lifecycle events of MCP servers.
+// Embed SimpleCallbacksHandler to implement only some of them.
+type CallbacksHandler interface {
+	callbacks.Handler
+	lcgomcptool.ProgressCallbackHandler
+	lcgomcp.PromptCallbackHandler
+
+	// HandleServerConnect is called when the session with a server is initialized.
+	HandleServerConnect(ctx context.Context, serverName string)
+	// HandleServerConnectError is called when connecting to or initializing a server fails.
+	HandleServerConnectError(ctx context.Context, serverName string, err error)
+	// HandleServerDisconnect is called when the session with a server is closed.
+	HandleServerDisconnect(ctx context.Context, serverName string)
+	// HandleToolsLoaded is called with the tools of a server when they are first loaded
+	// and whenever they are reloaded after the server announced a change.
+	HandleToolsLoaded(ctx context.Context, serverName string, tools []tools.Tool)
+	// HandleServerLog is called with every log message sent by a server.
+	HandleServerLog(ctx context.Context, log ServerLog)
+}
+
+// SimpleCallbacksHandler is a CallbacksHandler that does nothing.
+type SimpleCallbacksHandler struct {
+	callbacks.SimpleHandler
+}
+
+var _ CallbacksHandler = SimpleCallbacksHandler{}
+
+func (SimpleCallbacksHandler) HandleToolProgress(context.Context, lcgomcptool.Progress) {}
+func (SimpleCallbacksHandler) HandlePromptLoad(context.Context, lcgomcp.PromptLoad)     {}
+func (SimpleCallbacksHandler) HandleServerConnect(context.Context, string)              {}
+func (SimpleCallbacksHandler) HandleServerConnectError(context.Context, string, error)  {}
+func (SimpleCallbacksHandler) HandleServerDisconnect(context.Context, string)           {}
+func (SimpleCallbacksHandler) HandleToolsLoaded(context.Context, string, []tools.Tool)  {}
+func (SimpleCallbacksHandler) HandleServerLog(context.Context, ServerLog)               {}
+
+// WithCallbacksHandler notifies handler of the start, end and errors of tool calls. If
+// handler implements CallbacksHandler, it is also notified of tool progress, loaded prompts,
+// server logs, and servers connecting, disconnecting and changing their tools. Handlers
+// implementing lcgomcptool.TruncationCallbackHandler are notified of truncated tool outputs.
+func WithCallbacksHandler(handler callbacks.Handler) Option {
+	return func(c *MultiServerMCPClient) {
+		c.callbacks = handler
+	}
+}
+
+// mcpCallbacks returns the callbacks handler if it handles MCP lifecycle events.
+func (c *MultiServerMCPClient) mcpCallbacks() (CallbacksHandler, bool) {
+	handler, ok := c.callbacks.(CallbacksHandler)
+	return handler, ok
+}
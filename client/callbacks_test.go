@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	. "github.com/ovechkin-dm/mockio/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/tools"
+
+	lcgomcptool "github.com/akihiro-fukuchi/langchaingo-mcp-adapters/tool"
+)
+
+type FakeCallbacksHandler struct {
+	SimpleCallbacksHandler
+	mu     sync.Mutex
+	events []string
+	logs   []ServerLog
+}
+
+func (h *FakeCallbacksHandler) record(event string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, event)
+}
+
+func (h *FakeCallbacksHandler) recorded() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.events...)
+}
+
+func (h *FakeCallbacksHandler) HandleServerConnect(_ context.Context, serverName string) {
+	h.record("connect " + serverName)
+}
+
+func (h *FakeCallbacksHandler) HandleServerConnectError(_ context.Context, serverName string, _ error) {
+	h.record("connect_error " + serverName)
+}
+
+func (h *FakeCallbacksHandler) HandleServerDisconnect(_ context.Context, serverName string) {
+	h.record("disconnect " + serverName)
+}
+
+func (h *FakeCallbacksHandler) HandleToolsLoaded(_ context.Context, serverName string, loadedTools []tools.Tool) {
+	for _, loadedTool := range loadedTools {
+		h.record("tool " + serverName + " " + loadedTool.Name())
+	}
+}
+
+func (h *FakeCallbacksHandler) HandleServerLog(_ context.Context, log ServerLog) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.logs = append(h.logs, log)
+}
+
+func toolsListChangedNotification() mcp.JSONRPCNotification {
+	notification := mcp.JSONRPCNotification{JSONRPC: mcp.JSONRPC_VERSION}
+	notification.Method = methodNotificationToolsListChanged
+	return notification
+}
+
+func TestMultiServerMCPClient_Callbacks_Lifecycle(t *testing.T) {
+	SetUp(t)
+
+	mockClient := Mock[MockMCPClientInternal]()
+	When(mockClient.Initialize(Any[context.Context](), Any[mcp.InitializeRequest]())).ThenReturn(&mcp.InitializeResult{}, nil)
+	When(mockClient.ListTools(Any[context.Context](), Any[mcp.ListToolsRequest]())).
+		ThenReturn(&mcp.ListToolsResult{Tools: []mcp.Tool{{Name: "search"}}}, nil).
+		ThenReturn(&mcp.ListToolsResult{Tools: []mcp.Tool{{Name: "search"}, {Name: "fetch"}}}, nil)
+	When(mockClient.Close()).ThenReturn(nil)
+
+	handler := &FakeCallbacksHandler{}
+	conns := map[string]ConnectionConfig{"web": SSEConnection{}}
+	msc := NewMultiServerMCPClient(conns, mcp.Implementation{}, mcp.ClientCapabilities{}, WithCallbacksHandler(handler))
+	msc.sessions["web"] = mockClient
+
+	require.NoError(t, msc.initializeSessionAndLoadTools(context.Background(), "web", mockClient))
+
+	msc.notificationHandler("web", mockClient, lcgomcptool.NewProgressRouter())(toolsListChangedNotification())
+	require.Eventually(t, func() bool { return len(handler.recorded()) == 3 }, time.Second, 10*time.Millisecond)
+	assert.Len(t, msc.GetTools(), 2)
+
+	require.NoError(t, msc.Close())
+
+	assert.Equal(t, []string{"tool web search", "tool web search", "tool web fetch", "disconnect web"}, handler.recorded())
+}
+
+func TestMultiServerMCPClient_Callbacks_ConnectError(t *testing.T) {
+	handler := &FakeCallbacksHandler{}
+	conns := map[string]ConnectionConfig{"broken": struct{}{}}
+	msc := NewMultiServerMCPClient(conns, mcp.Implementation{}, mcp.ClientCapabilities{}, WithCallbacksHandler(handler))
+
+	err := msc.Start(context.Background())
+
+	require.Error(t, err)
+	assert.Equal(t, []string{"connect_error broken"}, handler.recorded())
+}
+
+func TestMultiServerMCPClient_Callbacks_ServerLog(t *testing.T) {
+	handler := &FakeCallbacksHandler{}
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{}, WithCallbacksHandler(handler))
+
+	msc.notificationHandler("indexer", nil, nil)(logMessageNotification(mcp.LoggingLevelWarning, "walker", "skipping large file"))
+
+	assert.Equal(t, []ServerLog{{
+		ServerName: "indexer",
+		Level:      mcp.LoggingLevelWarning,
+		Logger:     "walker",
+		Data:       "skipping large file",
+	}}, handler.logs)
+}
+
+func (h *FakeCallbacksHandler) HandleToolOutputTruncated(_ context.Context, truncation lcgomcptool.OutputTruncation) {
+	h.record("truncated " + truncation.ToolName)
+}
+
+func TestMultiServerMCPClient_Callbacks_OutputTruncated(t *testing.T) {
+	httpServer := httptest.NewServer(newEchoSSEServer())
+	defer httpServer.Close()
+
+	handler := &FakeCallbacksHandler{}
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{
+		"echo": SSEConnection{URL: httpServer.URL + "/sse"},
+	}, mcp.Implementation{}, mcp.ClientCapabilities{},
+		WithCallbacksHandler(handler),
+		WithToolOptions(lcgomcptool.WithOutputLimit(lcgomcptool.OutputLimit{MaxBytes: 50})),
+	)
+	require.NoError(t, msc.Start(context.Background()))
+	defer msc.Close()
+
+	loadedTools := msc.GetTools()
+	require.Len(t, loadedTools, 1)
+	output, err := loadedTools[0].Call(context.Background(), `{"text": "`+strings.Repeat("echo ", 20)+`"}`)
+
+	require.NoError(t, err)
+	assert.Len(t, output, 50)
+	assert.Contains(t, handler.recorded(), "truncated echo")
+}
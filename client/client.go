@@ -3,19 +3,27 @@ package client
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
-	"os"
+	"net/http"
+	"path/filepath"
 	"sync"
 	"time"
 
 	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/client/transport"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tmc/langchaingo/callbacks"
 	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/tools"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/auth"
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/internal/telemetry"
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/metrics"
 	lcgomcp "github.com/akihiro-fukuchi/langchaingo-mcp-adapters/prompt"
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/sampling"
 	lcgomcptool "github.com/akihiro-fukuchi/langchaingo-mcp-adapters/tool"
 )
 
@@ -32,34 +40,46 @@ const (
 	DefaultEncoding               = "utf-8"
 	DefaultEncodingErrorHandler   = Strict
 	DefaultHTTPTimeout            = 5 * time.Second
-	DefaultSSEReadTimeout         = 5 * 60 * time.Second
+	DefaultCredentialTTL          = 5 * time.Minute
 	DefaultStdioConnectionTimeout = 30 * time.Second
 )
 
 // StdioConnection defines parameters for connecting to an MCP server via stdio.
 type StdioConnection struct {
-	Transport              string               `json:"transport"` // Should always be "stdio"
-	Command                string               `json:"command"`
-	Args                   []string             `json:"args"`
-	Env                    map[string]string    `json:"env,omitempty"`
-	Cwd                    string               `json:"cwd,omitempty"`
-	Encoding               string               `json:"encoding,omitempty"`
-	EncodingErrorHandler   EncodingErrorHandler `json:"encoding_error_handler,omitempty"`
-	SessionKwargs          map[string]any       `json:"session_kwargs,omitempty"` // Note: mcp-go client doesn't directly support session kwargs like Python's mcp-sdk
-	ConnectionTimeout      time.Duration        `json:"-"`                        // Go specific timeout for establishing connection
-	InitializationTimeout  time.Duration        `json:"-"`                        // Go specific timeout for MCP initialize handshake
-	NotificationBufferSize int                  `json:"-"`                        // Go specific buffer size for notification channel
+	Transport              string                     `json:"transport"` // Should always be "stdio"
+	Command                string                     `json:"command"`
+	Args                   []string                   `json:"args"`
+	Env                    map[string]string          `json:"env,omitempty"`
+	Cwd                    string                     `json:"cwd,omitempty"`
+	Encoding               string                     `json:"encoding,omitempty"`
+	EncodingErrorHandler   EncodingErrorHandler       `json:"encoding_error_handler,omitempty"`
+	SessionKwargs          map[string]any             `json:"session_kwargs,omitempty"`  // Note: mcp-go client doesn't directly support session kwargs like Python's mcp-sdk
+	ConnectionTimeout      time.Duration              `json:"-"`                         // Go specific timeout for establishing connection
+	InitializationTimeout  time.Duration              `json:"-"`                         // Go specific timeout for MCP initialize handshake
+	NotificationBufferSize int                        `json:"-"`                         // Go specific buffer size for notification channel
+	LogLevel               mcp.LoggingLevel           `json:"log_level,omitempty"`       // Minimum server log level requested after initialize
+	EnvCredentials         map[string]auth.Credential `json:"-"`                         // Environment variables resolved when connecting, never logged
+	PassthroughEnv         []string                   `json:"passthrough_env,omitempty"` // Environment variables inherited in addition to DefaultInheritedEnv
+	InheritEnv             bool                       `json:"inherit_env,omitempty"`     // Inherit the whole environment of this process instead
+	Sandbox                *StdioSandbox              `json:"sandbox,omitempty"`         // Resource limits and identity of the subprocess
 }
 
 // SSEConnection defines parameters for connecting to an MCP server via SSE.
 type SSEConnection struct {
-	Transport             string            `json:"transport"` // Should always be "sse"
-	URL                   string            `json:"url"`
-	Headers               map[string]string `json:"headers,omitempty"`
-	Timeout               time.Duration     `json:"-"` // Go specific HTTP timeout
-	SSEReadTimeout        time.Duration     `json:"-"` // Go specific SSE read timeout
-	SessionKwargs         map[string]any    `json:"session_kwargs,omitempty"`
-	InitializationTimeout time.Duration     `json:"-"` // Go specific timeout for MCP initialize handshake
+	Transport             string                     `json:"transport"` // Should always be "sse"
+	URL                   string                     `json:"url"`
+	Headers               map[string]string          `json:"headers,omitempty"`
+	Timeout               time.Duration              `json:"-"` // Go specific timeout for connecting and for the response headers of every request
+	SSEReadTimeout        time.Duration              `json:"-"` // Go specific time without data after which the SSE stream is closed, 0 (the default) for none; the stream is not reopened until Reconnect
+	SessionKwargs         map[string]any             `json:"session_kwargs,omitempty"`
+	InitializationTimeout time.Duration              `json:"-"`                   // Go specific timeout for MCP initialize handshake
+	LogLevel              mcp.LoggingLevel           `json:"log_level,omitempty"` // Minimum server log level requested after initialize
+	Auth                  auth.Provider              `json:"-"`                   // Optional provider of access tokens, see auth.NewOAuthProvider
+	HeaderCredentials     map[string]auth.Credential `json:"-"`                   // Headers resolved when connecting and added to every request, never logged
+	CredentialTTL         time.Duration              `json:"-"`                   // Go specific time resolved HeaderCredentials are reused, defaults to DefaultCredentialTTL
+	HTTPClient            *http.Client               `json:"-"`                   // Optional client for custom transports, rejected if its Timeout is set as it would end the SSE stream
+	TLS                   *TLSConfig                 `json:"tls,omitempty"`       // Optional TLS settings such as a CA bundle or a client certificate for mutual TLS
+	ProxyURL              string                     `json:"proxy_url,omitempty"` // Optional proxy, defaults to the HTTP_PROXY and HTTPS_PROXY environment variables
 }
 
 // ConnectionConfig represents either an StdioConnection or SSEConnection.
@@ -75,6 +95,81 @@ type MultiServerMCPClient struct {
 	cancel             context.CancelFunc
 	clientInfo         mcp.Implementation
 	clientCapabilities mcp.ClientCapabilities
+	logger             *slog.Logger            // Receives the log messages of the client
+	serverLogger       *slog.Logger            // Receives log messages sent by servers
+	samplingHandler    client.SamplingHandler  // Answers sampling/createMessage requests from servers
+	roots              []mcp.Root              // Returned to servers for roots/list
+	elicitationHandler ElicitationHandler      // Answers elicitation/create requests from servers
+	promptCache        map[string][]mcp.Prompt // Prompts listed per server, nil unless caching is enabled
+
+	completionDebounce     time.Duration   // Delay of completion requests, 0 disables debouncing
+	completionDebouncer    debouncer       // Drops superseded completion requests
+	completionsUnsupported map[string]bool // Servers that answered completion/complete with method not found
+
+	toolOptions []lcgomcptool.Option // Applied to every loaded tool
+	telemetry   telemetry.Config     // Tracing configuration
+	metrics     metrics.Recorder     // Optional recorder of tool calls and sessions
+	callbacks   callbacks.Handler    // Optional handler of tool calls and server lifecycle events
+
+	healthChecker *healthChecker // Pings the sessions if enabled with WithHealthCheck
+	logPayloads   bool           // Whether tool arguments and results are logged
+
+	reconnectLocks map[string]*sync.Mutex // Serializes the reconnects of each server, guarded by mu
+}
+
+// Option configures a MultiServerMCPClient.
+type Option func(*MultiServerMCPClient)
+
+// WithLogger sets the logger of the client, its tools and the prompts it loads.
+// Log records about a server carry its name in the server_name attribute.
+// Defaults to slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *MultiServerMCPClient) {
+		c.logger = logger
+	}
+}
+
+// WithPayloadLogging includes the arguments and results of tool calls in debug logs.
+// They are redacted by default, as they may contain sensitive data.
+func WithPayloadLogging() Option {
+	return func(c *MultiServerMCPClient) {
+		c.logPayloads = true
+	}
+}
+
+// WithServerLogger sets the logger that receives log messages sent by servers
+// (notifications/message). Defaults to the logger of the client.
+func WithServerLogger(logger *slog.Logger) Option {
+	return func(c *MultiServerMCPClient) {
+		c.serverLogger = logger
+	}
+}
+
+// WithToolOptions applies opts to every tool loaded from the servers, for example
+// lcgomcptool.WithArgumentSignature to describe tool arguments to text-only agents.
+func WithToolOptions(opts ...lcgomcptool.Option) Option {
+	return func(c *MultiServerMCPClient) {
+		c.toolOptions = append(c.toolOptions, opts...)
+	}
+}
+
+// WithMetrics records the tool calls, open sessions and reconnects of every server with
+// recorder. See the metrics package for OpenTelemetry and Prometheus recorders.
+func WithMetrics(recorder metrics.Recorder) Option {
+	return func(c *MultiServerMCPClient) {
+		c.metrics = recorder
+	}
+}
+
+// WithSamplingHandler lets servers request LLM completions through handler
+// and advertises the sampling capability. See the sampling package for a handler
+// backed by a langchaingo model. Only stdio servers can send requests, as the SSE transport
+// of mcp-go does not support them.
+func WithSamplingHandler(handler client.SamplingHandler) Option {
+	return func(c *MultiServerMCPClient) {
+		c.samplingHandler = handler
+		c.clientCapabilities.Sampling = &struct{}{}
+	}
 }
 
 // NewMultiServerMCPClient creates a new client for managing multiple MCP server connections.
@@ -82,6 +177,7 @@ func NewMultiServerMCPClient(
 	connections map[string]ConnectionConfig,
 	clientInfo mcp.Implementation, // Optional client info
 	clientCapabilities mcp.ClientCapabilities, // Optional client capabilities
+	opts ...Option,
 ) *MultiServerMCPClient {
 	if clientInfo.Name == "" {
 		clientInfo.Name = "langchaingo-mcp-client"
@@ -89,88 +185,194 @@ func NewMultiServerMCPClient(
 	if clientInfo.Version == "" {
 		clientInfo.Version = "0.0.1" // TODO: Consider using a dynamic version
 	}
-	return &MultiServerMCPClient{
+	c := &MultiServerMCPClient{
 		connections:        connections,
 		sessions:           make(map[string]client.MCPClient),
 		serverNameToTools:  make(map[string][]tools.Tool),
 		clientInfo:         clientInfo,
 		clientCapabilities: clientCapabilities,
+
+		completionsUnsupported: make(map[string]bool),
+		healthChecker:          newHealthChecker(),
+		reconnectLocks:         make(map[string]*sync.Mutex),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	if c.logger == nil {
+		c.logger = slog.Default()
+	}
+	return c
+}
+
+// loggerFor returns the logger of the client bound to serverName.
+func (c *MultiServerMCPClient) loggerFor(serverName string) *slog.Logger {
+	return c.logger.With("server_name", serverName)
 }
 
 // Start establishes connections to all configured MCP servers and initializes them.
 // It returns an error if any connection or initialization fails.
-func (c *MultiServerMCPClient) Start(ctx context.Context) error {
-	slog.Debug("MultiServerMCPClient Start: Acquiring lock...")
+func (c *MultiServerMCPClient) Start(ctx context.Context) (err error) {
+	c.logger.Debug("MultiServerMCPClient Start: Acquiring lock...")
 	c.mu.Lock() // Lock at the beginning to prevent concurrent Start calls
-	slog.Debug("MultiServerMCPClient Start: Lock acquired.")
+	c.logger.Debug("MultiServerMCPClient Start: Lock acquired.")
 	// Unlock will happen explicitly before waiting, not deferred
 
 	if c.eg != nil {
-		slog.Debug("MultiServerMCPClient Start: Client already started.")
+		c.logger.Debug("MultiServerMCPClient Start: Client already started.")
 		c.mu.Unlock() // Unlock if already started
 		return fmt.Errorf("client already started")
 	}
+	if err := validateRoots(c.roots); err != nil {
+		c.mu.Unlock()
+		return err
+	}
 
-	slog.Debug("MultiServerMCPClient Start: Setting up context and errgroup...")
+	c.logger.Debug("MultiServerMCPClient Start: Setting up context and errgroup...")
+	ctx, span := c.telemetry.Tracer().Start(ctx, "MultiServerMCPClient.Start")
+	defer func() { telemetry.End(span, err) }()
 	ctx, c.cancel = context.WithCancel(ctx)
 	c.eg, ctx = errgroup.WithContext(ctx)
-	slog.Debug("MultiServerMCPClient Start: Starting connection loop", "server_count", len(c.connections))
+	c.logger.Debug("MultiServerMCPClient Start: Starting connection loop", "server_count", len(c.connections))
 
 	for serverName, config := range c.connections {
 		name := serverName
 		cfg := config
-		slog.Debug("MultiServerMCPClient Start: Launching goroutine", "server_name", name)
+		c.logger.Debug("MultiServerMCPClient Start: Launching goroutine", "server_name", name)
 
 		c.eg.Go(func() error {
-			slog.Debug("Goroutine starting connection", "server_name", name)
-			mcpClient, err := c.connectToServer(ctx, name, cfg)
-			if err != nil {
-				slog.Error("Goroutine failed to connect", "server_name", name, "error", err)
-				return fmt.Errorf("failed to connect to server %s: %w", name, err)
-			}
-			slog.Debug("Goroutine connection successful. Storing session...", "server_name", name)
-
-			c.mu.Lock()
-			slog.Debug("Goroutine acquired lock to store session", "server_name", name)
-			c.sessions[name] = mcpClient
-			c.mu.Unlock()
-			slog.Debug("Goroutine released lock after storing session", "server_name", name)
-
-			slog.Debug("Goroutine initializing session and loading tools...", "server_name", name)
-			if err := c.initializeSessionAndLoadTools(ctx, name, mcpClient); err != nil {
-				slog.Error("Goroutine failed to initialize/load tools", "server_name", name, "error", err)
-				slog.Debug("Goroutine attempting to close client due to init error...", "server_name", name)
-				_ = mcpClient.Close()
-				slog.Debug("Goroutine acquiring lock to delete session after init error...", "server_name", name)
-				c.mu.Lock()
-				delete(c.sessions, name)
-				c.mu.Unlock()
-				slog.Debug("Goroutine released lock after deleting session", "server_name", name)
-				return fmt.Errorf("failed to initialize/load tools for server %s: %w", name, err)
-			}
-			slog.Debug("Goroutine initialization and tool loading successful", "server_name", name)
-			return nil
+			return c.startSession(ctx, name, cfg)
 		})
 	}
 
 	// Unlock *before* waiting for goroutines, allowing them to acquire the lock
-	slog.Debug("MultiServerMCPClient Start: Releasing lock before waiting for goroutines...")
+	c.logger.Debug("MultiServerMCPClient Start: Releasing lock before waiting for goroutines...")
 	c.mu.Unlock()
-	slog.Debug("MultiServerMCPClient Start: Lock released.")
+	c.logger.Debug("MultiServerMCPClient Start: Lock released.")
 
-	slog.Debug("MultiServerMCPClient Start: Waiting for all connection goroutines to finish...")
-	err := c.eg.Wait()
+	c.logger.Debug("MultiServerMCPClient Start: Waiting for all connection goroutines to finish...")
+	err = c.eg.Wait()
 	if err != nil {
-		slog.Error("MultiServerMCPClient Start: Error occurred during connection/initialization", "error", err)
+		c.logger.Error("MultiServerMCPClient Start: Error occurred during connection/initialization", "error", err)
 	} else {
-		slog.Debug("MultiServerMCPClient Start: All connection goroutines finished successfully.")
+		c.logger.Debug("MultiServerMCPClient Start: All connection goroutines finished successfully.")
+		c.startHealthCheck()
 	}
 	return err
 }
 
+// startSession connects to serverName, initializes the session and loads its tools.
+func (c *MultiServerMCPClient) startSession(ctx context.Context, name string, cfg ConnectionConfig) error {
+	logger := c.loggerFor(name)
+	logger.Debug("Goroutine starting connection")
+	connectCtx, connectSpan := c.startServerSpan(ctx, "mcp.connect", name)
+	connectSpan.SetAttributes(telemetry.TransportKey.String(transportName(cfg)))
+	mcpClient, err := c.connectToServer(connectCtx, name, cfg)
+	telemetry.End(connectSpan, err)
+	if err != nil {
+		logger.Error("Goroutine failed to connect", "error", err)
+		err = fmt.Errorf("failed to connect to server %s: %w", name, err)
+		if handler, ok := c.mcpCallbacks(); ok {
+			handler.HandleServerConnectError(ctx, name, err)
+		}
+		return err
+	}
+	logger.Debug("Goroutine connection successful. Storing session...")
+
+	c.mu.Lock()
+	logger.Debug("Goroutine acquired lock to store session")
+	c.sessions[name] = mcpClient
+	c.mu.Unlock()
+	logger.Debug("Goroutine released lock after storing session")
+
+	logger.Debug("Goroutine initializing session and loading tools...")
+	if err := c.initializeSessionAndLoadTools(ctx, name, mcpClient); err != nil {
+		logger.Error("Goroutine failed to initialize/load tools", "error", err)
+		logger.Debug("Goroutine attempting to close client due to init error...")
+		_ = mcpClient.Close()
+		logger.Debug("Goroutine acquiring lock to delete session after init error...")
+		c.mu.Lock()
+		delete(c.sessions, name)
+		c.mu.Unlock()
+		logger.Debug("Goroutine released lock after deleting session")
+		err = fmt.Errorf("failed to initialize/load tools for server %s: %w", name, err)
+		if handler, ok := c.mcpCallbacks(); ok {
+			handler.HandleServerConnectError(ctx, name, err)
+		}
+		return err
+	}
+	c.resetHealth(name)
+	if c.metrics != nil {
+		c.metrics.RecordSessionOpened(ctx, name)
+	}
+	if handler, ok := c.mcpCallbacks(); ok {
+		handler.HandleServerConnect(ctx, name)
+	}
+	logger.Debug("Goroutine initialization and tool loading successful")
+	return nil
+}
+
+// Reconnect closes the session with serverName, if any, then connects to the server again and
+// reloads its tools. The tools of the server are removed from GetTools until the reconnect
+// succeeds, and tools returned before keep using the closed session. Concurrent reconnects of
+// the same server run one after the other.
+func (c *MultiServerMCPClient) Reconnect(ctx context.Context, serverName string) error {
+	logger := c.loggerFor(serverName)
+	c.mu.Lock()
+	config, ok := c.connections[serverName]
+	if !ok {
+		c.mu.Unlock()
+		return fmt.Errorf("unknown server: %s", serverName)
+	}
+	lock, ok := c.reconnectLocks[serverName]
+	if !ok {
+		lock = &sync.Mutex{}
+		c.reconnectLocks[serverName] = lock
+	}
+	c.mu.Unlock()
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	c.mu.Lock()
+	session, hasSession := c.sessions[serverName]
+	delete(c.sessions, serverName)
+	delete(c.serverNameToTools, serverName)
+	c.mu.Unlock()
+
+	logger.Debug("Reconnect closing session", "has_session", hasSession)
+	if hasSession {
+		if err := session.Close(); err != nil {
+			logger.Warn("Reconnect failed to close session", "error", err)
+		}
+		if c.metrics != nil {
+			c.metrics.RecordSessionClosed(ctx, serverName)
+		}
+		if handler, ok := c.mcpCallbacks(); ok {
+			handler.HandleServerDisconnect(ctx, serverName)
+		}
+	}
+	if c.metrics != nil {
+		c.metrics.RecordReconnect(ctx, serverName)
+	}
+	return c.startSession(ctx, serverName, config)
+}
+
 // Close terminates all active MCP server connections and waits for background tasks to finish.
 func (c *MultiServerMCPClient) Close() error {
+	// Notify the callbacks handler after releasing the lock, so that it may use the client
+	var closed []string
+	defer func() {
+		if handler, ok := c.mcpCallbacks(); ok {
+			for _, name := range closed {
+				handler.HandleServerDisconnect(context.Background(), name)
+			}
+		}
+	}()
+
+	// Stop pinging first, as the pings need the lock to find the sessions
+	c.stopHealthCheck()
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -183,8 +385,16 @@ func (c *MultiServerMCPClient) Close() error {
 		if err := session.Close(); err != nil {
 			closeErrors = append(closeErrors, fmt.Errorf("failed to close session %s: %w", name, err))
 		}
+		if c.metrics != nil {
+			c.metrics.RecordSessionClosed(context.Background(), name)
+		}
+		closed = append(closed, name)
 	}
 	c.sessions = make(map[string]client.MCPClient) // Clear sessions map
+	if c.promptCache != nil {
+		clear(c.promptCache)
+	}
+	clear(c.completionsUnsupported)
 
 	// Wait for errgroup goroutines to finish (if started)
 	var egErr error
@@ -215,7 +425,8 @@ func (c *MultiServerMCPClient) connectToServer(ctx context.Context, serverName s
 
 // connectToServerViaStdio connects to an MCP server using stdio.
 func (c *MultiServerMCPClient) connectToServerViaStdio(ctx context.Context, serverName string, config StdioConnection) (client.MCPClient, error) {
-	slog.Debug("connectToServerViaStdio starting...", "server_name", serverName)
+	logger := c.loggerFor(serverName)
+	logger.Debug("connectToServerViaStdio starting...")
 
 	if config.Encoding == "" {
 		config.Encoding = DefaultEncoding // mcp-go client doesn't use this directly, but good practice
@@ -227,40 +438,40 @@ func (c *MultiServerMCPClient) connectToServerViaStdio(ctx context.Context, serv
 		config.ConnectionTimeout = DefaultStdioConnectionTimeout
 	}
 
-	// Prepare environment variables
-	envList := os.Environ()
-	if config.Env != nil {
-		for k, v := range config.Env {
-			envList = append(envList, fmt.Sprintf("%s=%s", k, v))
-		}
-	}
-	// Ensure PATH is included if not explicitly set in config.Env
-	if _, pathSet := config.Env["PATH"]; !pathSet {
-		if pathVal, ok := os.LookupEnv("PATH"); ok {
-			envList = append(envList, fmt.Sprintf("PATH=%s", pathVal))
-		}
+	secrets, err := resolveCredentials(ctx, config.EnvCredentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve environment of %s: %w", serverName, err)
 	}
+	// Only variables that are allowed are inherited, so that secrets of this process do not leak
+	envList := stdioEnv(config, secrets)
 
 	connectCtx, cancel := context.WithTimeout(ctx, config.ConnectionTimeout)
 	defer cancel()
 
-	slog.Debug("connectToServerViaStdio creating NewStdioMCPClient", "server_name", serverName, "command", config.Command, "args", config.Args)
-	// mcp-go client handles command execution and stdio pipes internally
-	mcpClient, err := client.NewStdioMCPClient(config.Command, envList, config.Args...)
-	if err != nil {
-		slog.Error("connectToServerViaStdio failed to create stdio client", "server_name", serverName, "error", err)
+	// Arguments and environment are not logged, as they may contain secrets
+	logger.Debug("connectToServerViaStdio creating stdio client", "command", filepath.Base(config.Command), "arg_count", len(config.Args))
+	// mcp-go client handles command execution and stdio pipes internally.
+	// The subprocess lives until Close, so it is not bound to the connection context.
+	stdioTransport := transport.NewStdioWithOptions(config.Command, envList, config.Args, transport.WithCommandFunc(stdioCommand(config)))
+	var mcpTransport transport.Interface = stdioTransport
+	if c.samplingHandler != nil {
+		mcpTransport = requestParamsTransport{stdioTransport}
+	}
+	mcpClient := client.NewClient(mcpTransport, c.mcpClientOptions(serverName)...)
+	if err := mcpClient.Start(context.Background()); err != nil {
+		logger.Error("connectToServerViaStdio failed to create stdio client", "error", err)
 		return nil, fmt.Errorf("failed to start stdio client for %s: %w", serverName, err)
 	}
-	slog.Debug("connectToServerViaStdio stdio client created successfully", "server_name", serverName)
+	logger.Debug("connectToServerViaStdio stdio client created successfully")
 
 	// Check if context timed out during client creation/start
 	if connectCtx.Err() != nil {
-		slog.Error("connectToServerViaStdio context deadline exceeded during connection", "server_name", serverName, "error", connectCtx.Err())
+		logger.Error("connectToServerViaStdio context deadline exceeded during connection", "error", connectCtx.Err())
 		_ = mcpClient.Close() // Attempt cleanup
 		return nil, fmt.Errorf("context deadline exceeded while connecting to %s: %w", serverName, connectCtx.Err())
 	}
 
-	slog.Debug("connectToServerViaStdio connection successful", "server_name", serverName)
+	logger.Debug("connectToServerViaStdio connection successful")
 	return mcpClient, nil
 }
 
@@ -269,98 +480,259 @@ func (c *MultiServerMCPClient) connectToServerViaSSE(ctx context.Context, server
 	if config.Timeout == 0 {
 		config.Timeout = DefaultHTTPTimeout
 	}
-	if config.SSEReadTimeout == 0 {
-		config.SSEReadTimeout = DefaultSSEReadTimeout
+	if config.CredentialTTL == 0 {
+		config.CredentialTTL = DefaultCredentialTTL
 	}
+	config.HeaderCredentials = cacheCredentials(config.HeaderCredentials, config.CredentialTTL)
 
+	// Fail to connect rather than send requests without the credentials
+	if _, err := resolveCredentials(ctx, config.HeaderCredentials); err != nil {
+		return nil, fmt.Errorf("failed to resolve headers of %s: %w", serverName, err)
+	}
+
+	// Authorization may wait for the user to log in, so it is not limited by Timeout
+	if config.Auth != nil {
+		if _, err := config.Auth.Token(ctx); err != nil {
+			return nil, fmt.Errorf("failed to authorize %s: %w", serverName, err)
+		}
+	}
+
+	httpClient, err := sseHTTPClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure HTTP client for %s: %w", serverName, err)
+	}
 	opts := []transport.ClientOption{
-		client.WithHeaders(config.Headers),
-		// Note: mcp-go SSE client doesn't have a direct HTTP timeout option during creation,
-		// but the underlying http client might respect context deadlines.
+		transport.WithHeaders(config.Headers),
+		transport.WithHeaderFunc(c.telemetry.Headers),
+		transport.WithHTTPClient(httpClient),
 	}
 
-	mcpClient, err := client.NewSSEMCPClient(config.URL, opts...)
+	sseTransport, err := transport.NewSSE(config.URL, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create SSE client for %s: %w", serverName, err)
 	}
-
-	// Start the SSE connection process
-	startCtx, cancel := context.WithTimeout(ctx, config.Timeout)
-	defer cancel()
-	if err := mcpClient.Start(startCtx); err != nil {
+	// The SSE transport of mcp-go cannot answer requests sent by the server, see sessionCapabilities
+	mcpClient := client.NewClient(sseTransport)
+
+	// The event stream lives as long as the context passed to Start, so it must outlive ctx.
+	// The stream is only cancelled if ctx ends or the timeout expires before Start returns.
+	streamCtx, cancelStream := context.WithCancel(context.WithoutCancel(ctx))
+	timer := time.AfterFunc(config.Timeout, cancelStream)
+	stop := context.AfterFunc(ctx, cancelStream)
+	err = mcpClient.Start(streamCtx)
+	timedOut := !timer.Stop()
+	stop()
+	if err == nil && streamCtx.Err() != nil {
+		err = streamCtx.Err() // The stream ended right after the endpoint was received
+	}
+	if err != nil {
+		cancelStream()
 		_ = mcpClient.Close() // Attempt cleanup
+		if timedOut {
+			return nil, fmt.Errorf("timed out after %s starting SSE connection for %s: %w", config.Timeout, serverName, err)
+		}
 		return nil, fmt.Errorf("failed to start SSE connection for %s: %w", serverName, err)
 	}
 
 	return mcpClient, nil
 }
 
+// sessionCapabilities returns the capabilities advertised to a server connected with config.
+// The SSE transport of mcp-go cannot answer requests sent by the server, so sampling, roots and
+// elicitation are only advertised to stdio servers.
+func sessionCapabilities(capabilities mcp.ClientCapabilities, config ConnectionConfig) mcp.ClientCapabilities {
+	if _, ok := config.(SSEConnection); ok {
+		capabilities.Sampling = nil
+		capabilities.Roots = nil
+		capabilities.Elicitation = nil
+	}
+	return capabilities
+}
+
+// requestParamsTransport adds the raw params of the requests sent by the server to their context,
+// so that a sampling.Handler can tell an explicit temperature of 0 from a missing one. It hides
+// the stdio transport from client.GetStderr, use MultiServerMCPClient.Stderr instead.
+type requestParamsTransport struct {
+	transport.BidirectionalInterface
+}
+
+func (t requestParamsTransport) SetRequestHandler(handler transport.RequestHandler) {
+	t.BidirectionalInterface.SetRequestHandler(func(ctx context.Context, request transport.JSONRPCRequest) (*transport.JSONRPCResponse, error) {
+		return handler(sampling.ContextWithRequestParams(ctx, request.Params), request)
+	})
+}
+
+// mcpClientOptions returns the mcp-go client options for handling requests sent by serverName.
+func (c *MultiServerMCPClient) mcpClientOptions(serverName string) []client.ClientOption {
+	var opts []client.ClientOption
+	if c.samplingHandler != nil {
+		opts = append(opts, client.WithSamplingHandler(c.samplingHandler))
+	}
+	if c.clientCapabilities.Roots != nil {
+		opts = append(opts, client.WithRootsHandler(rootsHandler{c: c}))
+	}
+	if c.elicitationHandler != nil {
+		opts = append(opts, client.WithElicitationHandler(sessionElicitationHandler{serverName: serverName, handler: c.elicitationHandler, logger: c.loggerFor(serverName)}))
+	}
+	return opts
+}
+
 // initializeSessionAndLoadTools initializes the MCP session and loads tools.
 func (c *MultiServerMCPClient) initializeSessionAndLoadTools(ctx context.Context, serverName string, mcpClient client.MCPClient) error {
-	slog.Debug("initializeSessionAndLoadTools starting...", "server_name", serverName)
+	logger := c.loggerFor(serverName)
+	logger.Debug("initializeSessionAndLoadTools starting...")
 	timeout := DefaultStdioConnectionTimeout
-	slog.Debug("initializeSessionAndLoadTools acquiring read lock for config...", "server_name", serverName)
+	var logLevel mcp.LoggingLevel
+	logger.Debug("initializeSessionAndLoadTools acquiring read lock for config...")
 	c.mu.RLock()
-	slog.Debug("initializeSessionAndLoadTools read lock acquired", "server_name", serverName)
+	logger.Debug("initializeSessionAndLoadTools read lock acquired")
 	config, ok := c.connections[serverName]
 	c.mu.RUnlock()
-	slog.Debug("initializeSessionAndLoadTools read lock released", "server_name", serverName)
+	logger.Debug("initializeSessionAndLoadTools read lock released")
 	if ok {
-		slog.Debug("initializeSessionAndLoadTools found connection config", "server_name", serverName)
+		logger.Debug("initializeSessionAndLoadTools found connection config")
 		switch cfg := config.(type) {
 		case StdioConnection:
 			if cfg.InitializationTimeout > 0 {
 				timeout = cfg.InitializationTimeout
-				slog.Debug("initializeSessionAndLoadTools using Stdio InitializationTimeout", "server_name", serverName, "timeout", timeout)
+				logger.Debug("initializeSessionAndLoadTools using Stdio InitializationTimeout", "timeout", timeout)
 			}
+			logLevel = cfg.LogLevel
 		case SSEConnection:
 			if cfg.InitializationTimeout > 0 {
 				timeout = cfg.InitializationTimeout
-				slog.Debug("initializeSessionAndLoadTools using SSE InitializationTimeout", "server_name", serverName, "timeout", timeout)
+				logger.Debug("initializeSessionAndLoadTools using SSE InitializationTimeout", "timeout", timeout)
 			}
+			logLevel = cfg.LogLevel
 		}
 	} else {
-		slog.Debug("initializeSessionAndLoadTools no specific config found, using default timeout", "server_name", serverName, "timeout", timeout)
+		logger.Debug("initializeSessionAndLoadTools no specific config found, using default timeout", "timeout", timeout)
 	}
 
+	// Route progress notifications of this session to the tool calls that requested them
+	router := lcgomcptool.NewProgressRouter(lcgomcptool.WithRouterLogger(logger))
+	mcpClient.OnNotification(c.notificationHandler(serverName, mcpClient, router))
+
 	initCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	slog.Debug("initializeSessionAndLoadTools preparing InitializeRequest...", "server_name", serverName)
+	logger.Debug("initializeSessionAndLoadTools preparing InitializeRequest...")
 	initRequest := mcp.InitializeRequest{}
 	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
 	initRequest.Params.ClientInfo = c.clientInfo
-	initRequest.Params.Capabilities = c.clientCapabilities
-
-	slog.Debug("initializeSessionAndLoadTools sending Initialize request...", "server_name", serverName)
-	initResult, err := mcpClient.Initialize(initCtx, initRequest)
+	initRequest.Params.Capabilities = sessionCapabilities(c.clientCapabilities, config)
+
+	logger.Debug("initializeSessionAndLoadTools sending Initialize request...")
+	spanCtx, initSpan := c.startServerSpan(initCtx, string(mcp.MethodInitialize), serverName)
+	initResult, err := mcpClient.Initialize(spanCtx, initRequest)
+	if err == nil {
+		initSpan.SetAttributes(
+			attribute.String("mcp.server.info.name", initResult.ServerInfo.Name),
+			attribute.String("mcp.protocol.version", initResult.ProtocolVersion),
+		)
+	}
+	telemetry.End(initSpan, err)
 	if err != nil {
-		slog.Error("initializeSessionAndLoadTools Initialize failed", "server_name", serverName, "error", err)
+		logger.Error("initializeSessionAndLoadTools Initialize failed", "error", err)
 		return fmt.Errorf("MCP initialization failed for %s: %w", serverName, err)
 	}
-	slog.Debug("initializeSessionAndLoadTools Initialize successful", "server_name", serverName, "server_info_name", initResult.ServerInfo.Name, "server_info_version", initResult.ServerInfo.Version)
+	logger.Debug("initializeSessionAndLoadTools Initialize successful", "server_info_name", initResult.ServerInfo.Name, "server_info_version", initResult.ServerInfo.Version)
+
+	// Server logs are diagnostics only, so a failure to enable them does not fail the session
+	if err := c.setLogLevel(initCtx, serverName, mcpClient, initResult.Capabilities, logLevel); err != nil {
+		logger.Warn("initializeSessionAndLoadTools failed to set log level", "error", err)
+	}
 
-	slog.Debug("initializeSessionAndLoadTools loading tools...", "server_name", serverName)
-	// Declare loadedTools here, use '=' for err as it's already declared from Initialize
-	var loadedTools []tools.Tool
-	loadedTools, err = lcgomcptool.LoadMCPTools(ctx, mcpClient)
+	logger.Debug("initializeSessionAndLoadTools loading tools...")
+	loadedTools, err := c.loadTools(ctx, serverName, mcpClient, router)
 	if err != nil {
-		slog.Error("initializeSessionAndLoadTools failed to load tools", "server_name", serverName, "error", err)
-		return fmt.Errorf("failed to load tools for %s: %w", serverName, err)
+		logger.Error("initializeSessionAndLoadTools failed to load tools", "error", err)
+		return err
 	}
-	slog.Debug("initializeSessionAndLoadTools loaded tools", "server_name", serverName, "count", len(loadedTools))
+	logger.Debug("initializeSessionAndLoadTools loaded tools", "count", len(loadedTools))
 
-	slog.Debug("initializeSessionAndLoadTools acquiring write lock to store tools...", "server_name", serverName)
+	logger.Debug("initializeSessionAndLoadTools acquiring write lock to store tools...")
 	c.mu.Lock()
-	slog.Debug("initializeSessionAndLoadTools write lock acquired", "server_name", serverName)
+	logger.Debug("initializeSessionAndLoadTools write lock acquired")
 	c.serverNameToTools[serverName] = loadedTools
 	c.mu.Unlock()
-	slog.Debug("initializeSessionAndLoadTools write lock released", "server_name", serverName)
+	logger.Debug("initializeSessionAndLoadTools write lock released")
+	if handler, ok := c.mcpCallbacks(); ok {
+		handler.HandleToolsLoaded(ctx, serverName, loadedTools)
+	}
 
-	slog.Debug("initializeSessionAndLoadTools finished successfully", "server_name", serverName)
+	logger.Debug("initializeSessionAndLoadTools finished successfully")
 	return nil
 }
 
+// loadTools lists the tools of serverName and wraps them with the options of the client.
+func (c *MultiServerMCPClient) loadTools(ctx context.Context, serverName string, mcpClient client.MCPClient, router *lcgomcptool.ProgressRouter) ([]tools.Tool, error) {
+	toolOptions := append([]lcgomcptool.Option{
+		lcgomcptool.WithProgressRouter(router),
+		lcgomcptool.WithServerName(serverName),
+		lcgomcptool.WithTracerProvider(c.telemetry.TracerProvider),
+		lcgomcptool.WithPropagator(c.telemetry.Propagator),
+		lcgomcptool.WithMetrics(c.metrics),
+		lcgomcptool.WithLogger(c.logger),
+		lcgomcptool.WithCallbacksHandler(c.callbacks),
+	}, c.toolOptions...)
+	if c.logPayloads {
+		toolOptions = append(toolOptions, lcgomcptool.WithPayloadLogging())
+	}
+	loadedTools, err := lcgomcptool.LoadMCPTools(ctx, mcpClient, toolOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tools for %s: %w", serverName, err)
+	}
+	return loadedTools, nil
+}
+
+// reloadTools replaces the tools of serverName after the server announced that they changed.
+// It must not run on the goroutine delivering notifications, which also delivers the response.
+func (c *MultiServerMCPClient) reloadTools(serverName string, mcpClient client.MCPClient, router *lcgomcptool.ProgressRouter) {
+	logger := c.loggerFor(serverName)
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultStdioConnectionTimeout)
+	defer cancel()
+
+	loadedTools, err := c.loadTools(ctx, serverName, mcpClient, router)
+	if err != nil {
+		logger.Warn("reloadTools failed to load tools", "error", err)
+		return
+	}
+
+	c.mu.Lock()
+	current := c.sessions[serverName] == mcpClient
+	if current {
+		c.serverNameToTools[serverName] = loadedTools
+	}
+	c.mu.Unlock()
+	if !current {
+		logger.Debug("reloadTools session was replaced, dropping tools")
+		return
+	}
+	logger.Debug("reloadTools reloaded tools", "count", len(loadedTools))
+	if handler, ok := c.mcpCallbacks(); ok {
+		handler.HandleToolsLoaded(ctx, serverName, loadedTools)
+	}
+}
+
+// notificationHandler returns the handler registered with the session of serverName.
+// It dispatches notifications by method to the progress router and the server logger, and
+// reloads the tools of the session when they change.
+func (c *MultiServerMCPClient) notificationHandler(serverName string, mcpClient client.MCPClient, router *lcgomcptool.ProgressRouter) func(mcp.JSONRPCNotification) {
+	return func(notification mcp.JSONRPCNotification) {
+		switch notification.Method {
+		case lcgomcptool.MethodNotificationProgress:
+			router.HandleNotification(notification)
+		case methodNotificationMessage:
+			c.handleLogMessage(serverName, notification)
+		case methodNotificationPromptsListChanged:
+			c.invalidatePrompts(serverName)
+		case methodNotificationToolsListChanged:
+			go c.reloadTools(serverName, mcpClient, router)
+		}
+	}
+}
+
 // GetTools returns a combined list of all tools loaded from all connected servers.
 func (c *MultiServerMCPClient) GetTools() []tools.Tool {
 	c.mu.RLock()
@@ -373,6 +745,37 @@ func (c *MultiServerMCPClient) GetTools() []tools.Tool {
 	return allTools
 }
 
+// Session returns the MCP session of a connected server, to use the mcp-go client directly.
+func (c *MultiServerMCPClient) Session(serverName string) (client.MCPClient, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	session, ok := c.sessions[serverName]
+	if !ok {
+		return nil, fmt.Errorf("no active session for server: %s", serverName)
+	}
+	return session, nil
+}
+
+// Stderr returns the standard error of the subprocess of a stdio server. Use it rather than
+// client.GetStderr, which cannot find the subprocess of servers that may request sampling.
+func (c *MultiServerMCPClient) Stderr(serverName string) (io.Reader, error) {
+	session, err := c.Session(serverName)
+	if err != nil {
+		return nil, err
+	}
+	if mcpClient, ok := session.(*client.Client); ok {
+		mcpTransport := mcpClient.GetTransport()
+		if wrapped, ok := mcpTransport.(requestParamsTransport); ok {
+			mcpTransport = wrapped.BidirectionalInterface
+		}
+		if stdioTransport, ok := mcpTransport.(*transport.Stdio); ok {
+			return stdioTransport.Stderr(), nil
+		}
+	}
+	return nil, fmt.Errorf("server %s is not connected through stdio", serverName)
+}
+
 // GetPrompt retrieves a specific prompt from a named server.
 func (c *MultiServerMCPClient) GetPrompt(ctx context.Context, serverName string, promptName string, arguments map[string]string) ([]llms.ChatMessage, error) {
 	c.mu.RLock()
@@ -383,5 +786,10 @@ func (c *MultiServerMCPClient) GetPrompt(ctx context.Context, serverName string,
 		return nil, fmt.Errorf("no active session for server: %s", serverName)
 	}
 
-	return lcgomcp.LoadMCPPrompt(ctx, session, promptName, arguments)
+	return lcgomcp.LoadMCPPrompt(ctx, session, promptName, arguments,
+		lcgomcp.WithServerName(serverName),
+		lcgomcp.WithTracerProvider(c.telemetry.TracerProvider),
+		lcgomcp.WithLogger(c.loggerFor(serverName)),
+		lcgomcp.WithCallbacksHandler(c.callbacks),
+	)
 }
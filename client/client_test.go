@@ -1,19 +1,27 @@
 package client
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"errors"
+	"log/slog"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
 	"github.com/mark3labs/mcp-go/mcp"
 	. "github.com/ovechkin-dm/mockio/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/tools"
+
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/metrics"
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/sampling"
+	lcgomcptool "github.com/akihiro-fukuchi/langchaingo-mcp-adapters/tool"
 )
 
 // --- Mocks ---
@@ -324,3 +332,192 @@ func TestMultiServerMCPClient_GetPrompt_NoSession(t *testing.T) {
 	assert.Nil(t, lcMessages)
 	assert.ErrorContains(t, err, "no active session")
 }
+
+func TestMultiServerMCPClient_Session(t *testing.T) {
+	SetUp(t)
+
+	mockClient := Mock[MockMCPClientInternal]()
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{})
+	msc.sessions["server1"] = mockClient
+
+	session, err := msc.Session("server1")
+	require.NoError(t, err)
+	assert.Equal(t, mockClient, session)
+
+	_, err = msc.Session("nonexistent-server")
+	assert.ErrorContains(t, err, "no active session")
+}
+
+func TestMultiServerMCPClient_Stderr(t *testing.T) {
+	SetUp(t)
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{},
+		WithSamplingHandler(sampling.NewHandler(nil)))
+	session, err := msc.connectToServerViaStdio(context.Background(), "noisy", StdioConnection{
+		Command: "sh",
+		Args:    []string{"-c", "echo starting >&2; cat"},
+	})
+	require.NoError(t, err)
+	defer session.Close()
+	msc.sessions["noisy"] = session
+
+	_, ok := client.GetStderr(session.(*client.Client))
+	require.False(t, ok, "The stdio transport is wrapped to pass the params of sampling requests")
+	stderr, err := msc.Stderr("noisy")
+	require.NoError(t, err)
+	line, err := bufio.NewReader(stderr).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "starting\n", line)
+
+	msc.sessions["mock"] = Mock[MockMCPClientInternal]()
+	_, err = msc.Stderr("mock")
+	assert.EqualError(t, err, "server mock is not connected through stdio")
+}
+
+func TestNewMultiServerMCPClient_WithSamplingHandler(t *testing.T) {
+	handler := sampling.NewHandler(nil)
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{}, WithSamplingHandler(handler))
+
+	assert.Equal(t, handler, msc.samplingHandler)
+	assert.NotNil(t, msc.clientCapabilities.Sampling, "Sampling capability should be advertised")
+	assert.Len(t, msc.mcpClientOptions("server1"), 1)
+}
+
+func TestNewMultiServerMCPClient_WithToolOptions(t *testing.T) {
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{},
+		WithToolOptions(lcgomcptool.WithArgumentSignature()),
+		WithToolOptions(lcgomcptool.WithMaxDescriptionLength(200)),
+	)
+
+	assert.Len(t, msc.toolOptions, 2)
+}
+
+// FakeBidirectionalTransport keeps the request handler set by the mcp-go client.
+type FakeBidirectionalTransport struct {
+	transport.BidirectionalInterface
+	requestHandler transport.RequestHandler
+}
+
+func (t *FakeBidirectionalTransport) Start(context.Context) error                          { return nil }
+func (t *FakeBidirectionalTransport) SetNotificationHandler(func(mcp.JSONRPCNotification)) {}
+func (t *FakeBidirectionalTransport) SetRequestHandler(handler transport.RequestHandler) {
+	t.requestHandler = handler
+}
+
+// FakeSamplingModel records the temperature of its last call.
+type FakeSamplingModel struct {
+	temperature float64
+}
+
+func (m *FakeSamplingModel) GenerateContent(_ context.Context, _ []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	callOptions := llms.CallOptions{Temperature: 0.7} // Default of the model
+	for _, opt := range options {
+		opt(&callOptions)
+	}
+	m.temperature = callOptions.Temperature
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "ok"}}}, nil
+}
+
+func (m *FakeSamplingModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func TestRequestParamsTransport_SamplingTemperature(t *testing.T) {
+	model := &FakeSamplingModel{}
+	fakeTransport := &FakeBidirectionalTransport{}
+	mcpClient := client.NewClient(requestParamsTransport{fakeTransport}, client.WithSamplingHandler(sampling.NewHandler(model)))
+	require.NoError(t, mcpClient.Start(context.Background()))
+	require.NotNil(t, fakeTransport.requestHandler)
+
+	_, err := fakeTransport.requestHandler(context.Background(), transport.JSONRPCRequest{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		ID:      mcp.NewRequestId(1),
+		Method:  string(mcp.MethodSamplingCreateMessage),
+		Params: map[string]any{
+			"messages":    []any{map[string]any{"role": "user", "content": map[string]any{"type": "text", "text": "hi"}}},
+			"maxTokens":   64,
+			"temperature": 0,
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Zero(t, model.temperature, "An explicit temperature of 0 should reach the model")
+}
+
+type FakeRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *FakeRecorder) record(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *FakeRecorder) RecordToolCall(_ context.Context, serverName, toolName string, outcome metrics.Outcome, _ time.Duration) {
+	r.record("call " + serverName + "/" + toolName + "/" + string(outcome))
+}
+func (r *FakeRecorder) RecordSessionOpened(_ context.Context, serverName string) {
+	r.record("opened " + serverName)
+}
+func (r *FakeRecorder) RecordSessionClosed(_ context.Context, serverName string) {
+	r.record("closed " + serverName)
+}
+func (r *FakeRecorder) RecordReconnect(_ context.Context, serverName string) {
+	r.record("reconnect " + serverName)
+}
+
+func TestMultiServerMCPClient_Metrics(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClientInternal]()
+	When(mockClient.Initialize(Any[context.Context](), Any[mcp.InitializeRequest]())).ThenReturn(&mcp.InitializeResult{}, nil)
+	When(mockClient.ListTools(Any[context.Context](), Any[mcp.ListToolsRequest]())).
+		ThenReturn(&mcp.ListToolsResult{Tools: []mcp.Tool{{Name: "add"}}}, nil)
+	When(mockClient.CallTool(Any[context.Context](), Any[mcp.CallToolRequest]())).ThenReturn(mcp.NewToolResultText("8"), nil)
+	When(mockClient.Close()).ThenReturn(nil)
+
+	recorder := &FakeRecorder{}
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{"math": struct{}{}}, mcp.Implementation{}, mcp.ClientCapabilities{},
+		WithMetrics(recorder))
+	msc.sessions["math"] = mockClient
+	require.NoError(t, msc.initializeSessionAndLoadTools(context.Background(), "math", mockClient))
+
+	_, err := msc.GetTools()[0].Call(context.Background(), `{"a": 3, "b": 5}`)
+	require.NoError(t, err)
+
+	// The unknown connection type fails the reconnect after the session is closed
+	err = msc.Reconnect(context.Background(), "math")
+	require.Error(t, err)
+	assert.NotContains(t, msc.sessions, "math")
+	assert.Empty(t, msc.GetTools(), "Tools of a failed reconnect should not use the closed session")
+
+	assert.Equal(t, []string{"call math/add/success", "closed math", "reconnect math"}, recorder.events)
+	Verify(mockClient, Once()).Close()
+}
+
+func TestMultiServerMCPClient_Reconnect_UnknownServer(t *testing.T) {
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{})
+
+	err := msc.Reconnect(context.Background(), "missing")
+	assert.EqualError(t, err, "unknown server: missing")
+}
+
+func TestNewMultiServerMCPClient_WithLogger(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClientInternal]()
+	When(mockClient.Initialize(Any[context.Context](), Any[mcp.InitializeRequest]())).ThenReturn(&mcp.InitializeResult{}, nil)
+	When(mockClient.ListTools(Any[context.Context](), Any[mcp.ListToolsRequest]())).
+		ThenReturn(&mcp.ListToolsResult{Tools: []mcp.Tool{{Name: "add"}}}, nil)
+	When(mockClient.CallTool(Any[context.Context](), Any[mcp.CallToolRequest]())).ThenReturn(mcp.NewToolResultText("8"), nil)
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{}, WithLogger(logger))
+	require.NoError(t, msc.initializeSessionAndLoadTools(context.Background(), "math", mockClient))
+	_, err := msc.GetTools()[0].Call(context.Background(), `{"a": 3, "b": 5}`)
+	require.NoError(t, err)
+
+	assert.Contains(t, logs.String(), `msg="initializeSessionAndLoadTools finished successfully" server_name=math`)
+	assert.Contains(t, logs.String(), `msg="LangchainMCPTool.Call returning output" tool_name=add server_name=math output=[redacted]`)
+	assert.NotContains(t, logs.String(), "output=8")
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+var (
+	// ErrCompletionsNotSupported is returned when a server does not support completion/complete.
+	ErrCompletionsNotSupported = errors.New("server does not support completions")
+	// ErrCompletionSuperseded is returned by a debounced completion request when a newer
+	// request for the same argument arrives before it is sent.
+	ErrCompletionSuperseded = errors.New("completion superseded by a newer request")
+)
+
+// Completion holds the values suggested by a server for an argument.
+type Completion struct {
+	Values  []string
+	Total   int  // Total number of values available, 0 if unknown
+	HasMore bool // Whether more values exist than were returned
+}
+
+// WithCompletionDebounce delays completion requests by delay. A request that is followed by
+// another request for the same argument within delay is dropped with ErrCompletionSuperseded,
+// so only the last of a burst of keystrokes reaches the server.
+func WithCompletionDebounce(delay time.Duration) Option {
+	return func(c *MultiServerMCPClient) {
+		c.completionDebounce = delay
+	}
+}
+
+// CompletePromptArgument asks serverName to complete the argument of a prompt from its partial value.
+func (c *MultiServerMCPClient) CompletePromptArgument(ctx context.Context, serverName, promptName, argumentName, value string) (Completion, error) {
+	ref := mcp.PromptReference{Type: "ref/prompt", Name: promptName}
+	return c.complete(ctx, serverName, ref, "prompt:"+promptName, argumentName, value)
+}
+
+// CompleteResourceTemplateVar asks serverName to complete a variable of a resource URI template
+// from its partial value.
+func (c *MultiServerMCPClient) CompleteResourceTemplateVar(ctx context.Context, serverName, uriTemplate, variableName, value string) (Completion, error) {
+	ref := mcp.ResourceReference{Type: "ref/resource", URI: uriTemplate}
+	return c.complete(ctx, serverName, ref, "resource:"+uriTemplate, variableName, value)
+}
+
+// complete sends completion/complete to serverName. mcp-go does not expose the completions
+// capability of servers, so support is detected from the first request and remembered.
+func (c *MultiServerMCPClient) complete(ctx context.Context, serverName string, ref any, refKey, argumentName, value string) (Completion, error) {
+	c.mu.RLock()
+	session, ok := c.sessions[serverName]
+	unsupported := c.completionsUnsupported[serverName]
+	c.mu.RUnlock()
+	if !ok {
+		return Completion{}, fmt.Errorf("no active session for server: %s", serverName)
+	}
+	if unsupported {
+		return Completion{}, fmt.Errorf("%w: %s", ErrCompletionsNotSupported, serverName)
+	}
+
+	if c.completionDebounce > 0 {
+		key := serverName + "\x00" + refKey + "\x00" + argumentName
+		if err := c.completionDebouncer.wait(ctx, key, c.completionDebounce); err != nil {
+			return Completion{}, err
+		}
+	}
+
+	request := mcp.CompleteRequest{}
+	request.Params.Ref = ref
+	request.Params.Argument.Name = argumentName
+	request.Params.Argument.Value = value
+	result, err := session.Complete(ctx, request)
+	if err != nil {
+		if errors.Is(err, mcp.ErrMethodNotFound) {
+			c.loggerFor(serverName).Debug("complete server does not support completions")
+			c.mu.Lock()
+			c.completionsUnsupported[serverName] = true
+			c.mu.Unlock()
+			return Completion{}, fmt.Errorf("%w: %s", ErrCompletionsNotSupported, serverName)
+		}
+		return Completion{}, fmt.Errorf("failed to complete argument %s on %s: %w", argumentName, serverName, err)
+	}
+
+	return Completion{
+		Values:  result.Completion.Values,
+		Total:   result.Completion.Total,
+		HasMore: result.Completion.HasMore,
+	}, nil
+}
+
+// debouncer lets only the last of a burst of calls with the same key proceed.
+type debouncer struct {
+	mu      sync.Mutex
+	pending map[string]chan struct{}
+}
+
+// wait blocks for delay. It returns ErrCompletionSuperseded as soon as another call with
+// the same key starts waiting.
+func (d *debouncer) wait(ctx context.Context, key string, delay time.Duration) error {
+	superseded := make(chan struct{})
+	d.mu.Lock()
+	if d.pending == nil {
+		d.pending = make(map[string]chan struct{})
+	}
+	if previous, ok := d.pending[key]; ok {
+		close(previous)
+	}
+	d.pending[key] = superseded
+	d.mu.Unlock()
+
+	defer func() {
+		d.mu.Lock()
+		if d.pending[key] == superseded {
+			delete(d.pending, key)
+		}
+		d.mu.Unlock()
+	}()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-superseded:
+		return ErrCompletionSuperseded
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	. "github.com/ovechkin-dm/mockio/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func completeResult(values []string, total int, hasMore bool) *mcp.CompleteResult {
+	result := &mcp.CompleteResult{}
+	result.Completion.Values = values
+	result.Completion.Total = total
+	result.Completion.HasMore = hasMore
+	return result
+}
+
+func completeRequest(ref any, name, value string) mcp.CompleteRequest {
+	request := mcp.CompleteRequest{}
+	request.Params.Ref = ref
+	request.Params.Argument.Name = name
+	request.Params.Argument.Value = value
+	return request
+}
+
+func TestMultiServerMCPClient_CompletePromptArgument(t *testing.T) {
+	SetUp(t)
+
+	mockClient := Mock[MockMCPClientInternal]()
+	expectedRequest := completeRequest(mcp.PromptReference{Type: "ref/prompt", Name: "code_review"}, "language", "py")
+	When(mockClient.Complete(Any[context.Context](), Equal(expectedRequest))).
+		ThenReturn(completeResult([]string{"python", "pytorch"}, 10, true), nil)
+
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{})
+	msc.sessions["server1"] = mockClient
+
+	completion, err := msc.CompletePromptArgument(context.Background(), "server1", "code_review", "language", "py")
+
+	require.NoError(t, err)
+	assert.Equal(t, Completion{Values: []string{"python", "pytorch"}, Total: 10, HasMore: true}, completion)
+
+	_, err = msc.CompletePromptArgument(context.Background(), "unknown", "code_review", "language", "py")
+	assert.ErrorContains(t, err, "no active session")
+}
+
+func TestMultiServerMCPClient_CompleteResourceTemplateVar(t *testing.T) {
+	SetUp(t)
+
+	mockClient := Mock[MockMCPClientInternal]()
+	expectedRequest := completeRequest(mcp.ResourceReference{Type: "ref/resource", URI: "file:///{path}"}, "path", "src/")
+	When(mockClient.Complete(Any[context.Context](), Equal(expectedRequest))).
+		ThenReturn(completeResult([]string{"src/main.go"}, 0, false), nil)
+
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{})
+	msc.sessions["server1"] = mockClient
+
+	completion, err := msc.CompleteResourceTemplateVar(context.Background(), "server1", "file:///{path}", "path", "src/")
+
+	require.NoError(t, err)
+	assert.Equal(t, Completion{Values: []string{"src/main.go"}}, completion)
+}
+
+func TestMultiServerMCPClient_Complete_NotSupported(t *testing.T) {
+	SetUp(t)
+
+	mockClient := Mock[MockMCPClientInternal]()
+	When(mockClient.Complete(Any[context.Context](), Any[mcp.CompleteRequest]())).
+		ThenReturn(nil, fmt.Errorf("request failed: %w", mcp.ErrMethodNotFound))
+
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{})
+	msc.sessions["server1"] = mockClient
+
+	for range 2 {
+		_, err := msc.CompletePromptArgument(context.Background(), "server1", "p", "a", "")
+		assert.ErrorIs(t, err, ErrCompletionsNotSupported)
+	}
+	Verify(mockClient, Once()).Complete(Any[context.Context](), Any[mcp.CompleteRequest]())
+}
+
+func TestMultiServerMCPClient_Complete_Error(t *testing.T) {
+	SetUp(t)
+
+	mockClient := Mock[MockMCPClientInternal]()
+	When(mockClient.Complete(Any[context.Context](), Any[mcp.CompleteRequest]())).ThenReturn(nil, errors.New("timeout"))
+
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{})
+	msc.sessions["server1"] = mockClient
+
+	_, err := msc.CompletePromptArgument(context.Background(), "server1", "p", "a", "")
+	assert.ErrorContains(t, err, "timeout")
+	assert.NotErrorIs(t, err, ErrCompletionsNotSupported)
+}
+
+func TestMultiServerMCPClient_Complete_Debounce(t *testing.T) {
+	SetUp(t)
+
+	mockClient := Mock[MockMCPClientInternal]()
+	When(mockClient.Complete(Any[context.Context](), Any[mcp.CompleteRequest]())).
+		ThenReturn(completeResult([]string{"python"}, 0, false), nil)
+
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{}, WithCompletionDebounce(200*time.Millisecond))
+	msc.sessions["server1"] = mockClient
+
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := msc.CompletePromptArgument(context.Background(), "server1", "code_review", "language", "p")
+		firstErr <- err
+	}()
+	require.Eventually(t, func() bool {
+		msc.completionDebouncer.mu.Lock()
+		defer msc.completionDebouncer.mu.Unlock()
+		return len(msc.completionDebouncer.pending) == 1
+	}, time.Second, time.Millisecond, "The first request should be waiting")
+
+	completion, err := msc.CompletePromptArgument(context.Background(), "server1", "code_review", "language", "py")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"python"}, completion.Values)
+	assert.ErrorIs(t, <-firstErr, ErrCompletionSuperseded)
+	Verify(mockClient, Once()).Complete(Any[context.Context](), Any[mcp.CompleteRequest]())
+}
+
+func TestDebouncer_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var d debouncer
+	err := d.wait(ctx, "key", time.Minute)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, d.pending)
+}
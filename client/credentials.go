@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/auth"
+)
+
+// resolveCredentials resolves credentials by name. The error names the credential that
+// failed, but never contains a secret.
+func resolveCredentials(ctx context.Context, credentials map[string]auth.Credential) (map[string]string, error) {
+	values := make(map[string]string, len(credentials))
+	for _, name := range slices.Sorted(maps.Keys(credentials)) {
+		value, err := credentials[name].Resolve(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve credential %s: %w", name, err)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// cacheCredentials wraps credentials so that their values are reused for ttl instead of being
+// resolved for every request.
+func cacheCredentials(credentials map[string]auth.Credential, ttl time.Duration) map[string]auth.Credential {
+	cached := make(map[string]auth.Credential, len(credentials))
+	for name, credential := range credentials {
+		cached[name] = auth.CachedCredential(credential, ttl)
+	}
+	return cached
+}
+
+// credentialTransport adds credentials as headers to every request. A request whose
+// credentials cannot be resolved fails, rather than being sent without them.
+type credentialTransport struct {
+	base        http.RoundTripper
+	credentials map[string]auth.Credential
+}
+
+func (t *credentialTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	values, err := resolveCredentials(req.Context(), t.credentials)
+	if err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	for name, value := range values {
+		req.Header.Set(name, value)
+	}
+	return t.base.RoundTrip(req)
+}
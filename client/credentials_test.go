@@ -0,0 +1,129 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/auth"
+)
+
+func TestMultiServerMCPClient_ConnectViaSSE_HeaderCredentials(t *testing.T) {
+	sseServer := server.NewSSEServer(server.NewMCPServer("protected", "1.0.0"))
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		sseServer.ServeHTTP(w, r)
+	}))
+	defer httpServer.Close()
+
+	t.Setenv("MCP_TEST_API_KEY", "secret")
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{})
+
+	session, err := msc.connectToServerViaSSE(context.Background(), "protected", SSEConnection{
+		URL:               httpServer.URL + "/sse",
+		HeaderCredentials: map[string]auth.Credential{"X-API-Key": auth.EnvCredential("MCP_TEST_API_KEY")},
+	})
+	require.NoError(t, err)
+	assert.NoError(t, session.Close())
+
+	failing := auth.CredentialFunc(func(context.Context) (string, error) { return "", errors.New("vault sealed") })
+	_, err = msc.connectToServerViaSSE(context.Background(), "protected", SSEConnection{
+		URL:               httpServer.URL + "/sse",
+		HeaderCredentials: map[string]auth.Credential{"X-API-Key": failing},
+	})
+	require.ErrorContains(t, err, "failed to resolve headers of protected: failed to resolve credential X-API-Key: vault sealed")
+}
+
+func TestMultiServerMCPClient_ConnectViaSSE_HeaderCredentialsPerRequest(t *testing.T) {
+	sseServer := server.NewSSEServer(server.NewMCPServer("protected", "1.0.0"))
+	var unauthenticated atomic.Int32
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "secret" {
+			unauthenticated.Add(1)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		sseServer.ServeHTTP(w, r)
+	}))
+	defer httpServer.Close()
+
+	var resolutions atomic.Int32
+	var sealed atomic.Bool
+	credential := auth.CredentialFunc(func(context.Context) (string, error) {
+		resolutions.Add(1)
+		if sealed.Load() {
+			return "", errors.New("vault sealed")
+		}
+		return "secret", nil
+	})
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{})
+
+	session, err := msc.connectToServerViaSSE(context.Background(), "protected", SSEConnection{
+		URL:               httpServer.URL + "/sse",
+		HeaderCredentials: map[string]auth.Credential{"X-API-Key": credential},
+	})
+	require.NoError(t, err)
+	defer session.Close()
+	_, err = session.Initialize(context.Background(), mcp.InitializeRequest{})
+	require.NoError(t, err)
+	require.NoError(t, session.Ping(context.Background()))
+	assert.Equal(t, int32(1), resolutions.Load(), "Resolved values are reused within CredentialTTL")
+
+	session, err = msc.connectToServerViaSSE(context.Background(), "protected", SSEConnection{
+		URL:               httpServer.URL + "/sse",
+		HeaderCredentials: map[string]auth.Credential{"X-API-Key": credential},
+		CredentialTTL:     time.Nanosecond,
+	})
+	require.NoError(t, err)
+	defer session.Close()
+	_, err = session.Initialize(context.Background(), mcp.InitializeRequest{})
+	require.NoError(t, err)
+	sealed.Store(true)
+
+	err = session.Ping(context.Background())
+	require.ErrorContains(t, err, "failed to resolve credential X-API-Key: vault sealed")
+	assert.Zero(t, unauthenticated.Load(), "Requests are not sent without their credentials")
+}
+
+func TestMultiServerMCPClient_ConnectViaStdio_DoesNotLogSecrets(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{}, WithLogger(logger))
+
+	secret := auth.CredentialFunc(func(context.Context) (string, error) { return "env-secret", nil })
+	session, err := msc.connectToServerViaStdio(context.Background(), "cat", StdioConnection{
+		Command:        "cat",
+		Args:           []string{"--token=arg-secret"},
+		EnvCredentials: map[string]auth.Credential{"API_KEY": secret},
+	})
+	require.NoError(t, err)
+	defer session.Close()
+
+	assert.Contains(t, buf.String(), "command=cat arg_count=1")
+	assert.NotContains(t, buf.String(), "arg-secret")
+	assert.NotContains(t, buf.String(), "env-secret")
+}
+
+func TestResolveCredentials(t *testing.T) {
+	values, err := resolveCredentials(context.Background(), map[string]auth.Credential{
+		"A": auth.CredentialFunc(func(context.Context) (string, error) { return "1", nil }),
+		"B": auth.CredentialFunc(func(context.Context) (string, error) { return "2", nil }),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"A": "1", "B": "2"}, values)
+}
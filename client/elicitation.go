@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/internal/jsonschema"
+)
+
+// ElicitationRequest is a request from a server for structured input from the user.
+type ElicitationRequest struct {
+	ServerName string         // Name of the server that sent the request
+	Message    string         // Explains what information is requested and why
+	Schema     map[string]any // JSON Schema the accepted content must conform to
+}
+
+// ElicitationHandler answers elicitation requests from servers, e.g. by rendering a form.
+// Accepted content is validated against the requested schema before it is returned to the server.
+type ElicitationHandler interface {
+	Elicit(ctx context.Context, request ElicitationRequest) (mcp.ElicitationResponse, error)
+}
+
+// ElicitationHandlerFunc adapts a function to the ElicitationHandler interface.
+type ElicitationHandlerFunc func(ctx context.Context, request ElicitationRequest) (mcp.ElicitationResponse, error)
+
+// Elicit calls f(ctx, request).
+func (f ElicitationHandlerFunc) Elicit(ctx context.Context, request ElicitationRequest) (mcp.ElicitationResponse, error) {
+	return f(ctx, request)
+}
+
+// DeclineElicitationHandler declines every elicitation request.
+var DeclineElicitationHandler ElicitationHandler = ElicitationHandlerFunc(
+	func(ctx context.Context, request ElicitationRequest) (mcp.ElicitationResponse, error) {
+		return mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionDecline}, nil
+	},
+)
+
+// WithElicitationHandler lets servers request user input through handler and advertises
+// the elicitation capability. A nil handler uses DeclineElicitationHandler. Only stdio servers
+// can send requests, as the SSE transport of mcp-go does not support them.
+func WithElicitationHandler(handler ElicitationHandler) Option {
+	return func(c *MultiServerMCPClient) {
+		if handler == nil {
+			handler = DeclineElicitationHandler
+		}
+		c.elicitationHandler = handler
+		c.clientCapabilities.Elicitation = &struct{}{}
+	}
+}
+
+// ValidateElicitationContent checks accepted content against the schema of the request.
+// Handlers can use it to re-prompt the user before answering.
+func ValidateElicitationContent(request ElicitationRequest, content any) error {
+	return jsonschema.Validate(request.Schema, content)
+}
+
+// sessionElicitationHandler adapts the client's ElicitationHandler to a single session.
+type sessionElicitationHandler struct {
+	serverName string
+	handler    ElicitationHandler
+	logger     *slog.Logger // Logger bound to the server name
+}
+
+var _ client.ElicitationHandler = sessionElicitationHandler{}
+
+// Elicit implements client.ElicitationHandler.
+func (h sessionElicitationHandler) Elicit(ctx context.Context, request mcp.ElicitationRequest) (*mcp.ElicitationResult, error) {
+	schema, err := jsonschema.Normalize(request.Params.RequestedSchema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid requested schema from %s: %w", h.serverName, err)
+	}
+	elicitationRequest := ElicitationRequest{
+		ServerName: h.serverName,
+		Message:    request.Params.Message,
+		Schema:     schema,
+	}
+
+	response, err := h.handler.Elicit(ctx, elicitationRequest)
+	if err != nil {
+		return nil, fmt.Errorf("elicitation for %s failed: %w", h.serverName, err)
+	}
+	h.logger.Debug("Elicitation answered", "action", response.Action)
+
+	switch response.Action {
+	case mcp.ElicitationResponseActionAccept:
+		if err := ValidateElicitationContent(elicitationRequest, response.Content); err != nil {
+			return nil, fmt.Errorf("elicitation response for %s does not match the requested schema: %w", h.serverName, err)
+		}
+	case mcp.ElicitationResponseActionDecline, mcp.ElicitationResponseActionCancel:
+		response.Content = nil // Content is only sent with accept
+	default:
+		return nil, fmt.Errorf("invalid elicitation action %q", response.Action)
+	}
+
+	return &mcp.ElicitationResult{ElicitationResponse: response}, nil
+}
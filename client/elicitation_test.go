@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func elicitationRequest(message string, schema any) mcp.ElicitationRequest {
+	request := mcp.ElicitationRequest{}
+	request.Params.Message = message
+	request.Params.RequestedSchema = schema
+	return request
+}
+
+var contactSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"email": map[string]any{"type": "string"},
+		"age":   map[string]any{"type": "integer", "minimum": 0},
+	},
+	"required": []string{"email"},
+}
+
+func TestNewMultiServerMCPClient_WithElicitationHandler(t *testing.T) {
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{}, WithElicitationHandler(nil))
+
+	assert.NotNil(t, msc.clientCapabilities.Elicitation, "Elicitation capability should be advertised")
+	assert.Len(t, msc.mcpClientOptions("server1"), 1)
+
+	handler := sessionElicitationHandler{serverName: "server1", handler: msc.elicitationHandler, logger: slog.Default()}
+	result, err := handler.Elicit(context.Background(), elicitationRequest("Your email?", contactSchema))
+
+	require.NoError(t, err)
+	assert.Equal(t, mcp.ElicitationResponseActionDecline, result.Action, "The default handler should decline")
+}
+
+func TestSessionElicitationHandler_Accept(t *testing.T) {
+	var received ElicitationRequest
+	handler := sessionElicitationHandler{
+		serverName: "crm",
+		logger:     slog.Default(),
+		handler: ElicitationHandlerFunc(func(ctx context.Context, request ElicitationRequest) (mcp.ElicitationResponse, error) {
+			received = request
+			return mcp.ElicitationResponse{
+				Action:  mcp.ElicitationResponseActionAccept,
+				Content: map[string]any{"email": "a@example.com", "age": 42},
+			}, nil
+		}),
+	}
+
+	result, err := handler.Elicit(context.Background(), elicitationRequest("Your email?", contactSchema))
+
+	require.NoError(t, err)
+	assert.Equal(t, mcp.ElicitationResponseActionAccept, result.Action)
+	assert.Equal(t, map[string]any{"email": "a@example.com", "age": 42}, result.Content)
+	assert.Equal(t, "crm", received.ServerName)
+	assert.Equal(t, "Your email?", received.Message)
+	assert.Equal(t, "object", received.Schema["type"])
+}
+
+func TestSessionElicitationHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		response      mcp.ElicitationResponse
+		err           error
+		errorContains string
+	}{
+		{
+			name:          "Content does not match schema",
+			response:      mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionAccept, Content: map[string]any{"age": -1}},
+			errorContains: "does not match the requested schema",
+		},
+		{
+			name:          "Invalid action",
+			response:      mcp.ElicitationResponse{Action: "maybe"},
+			errorContains: "invalid elicitation action",
+		},
+		{
+			name:          "Handler error",
+			err:           errors.New("form closed"),
+			errorContains: "form closed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := sessionElicitationHandler{
+				serverName: "crm",
+				logger:     slog.Default(),
+				handler: ElicitationHandlerFunc(func(ctx context.Context, request ElicitationRequest) (mcp.ElicitationResponse, error) {
+					return tt.response, tt.err
+				}),
+			}
+
+			result, err := handler.Elicit(context.Background(), elicitationRequest("Your email?", contactSchema))
+
+			assert.Nil(t, result)
+			assert.ErrorContains(t, err, tt.errorContains)
+		})
+	}
+}
+
+func TestSessionElicitationHandler_CancelDropsContent(t *testing.T) {
+	handler := sessionElicitationHandler{
+		serverName: "crm",
+		logger:     slog.Default(),
+		handler: ElicitationHandlerFunc(func(ctx context.Context, request ElicitationRequest) (mcp.ElicitationResponse, error) {
+			return mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionCancel, Content: map[string]any{"email": 1}}, nil
+		}),
+	}
+
+	result, err := handler.Elicit(context.Background(), elicitationRequest("Your email?", contactSchema))
+
+	require.NoError(t, err)
+	assert.Equal(t, mcp.ElicitationResponseActionCancel, result.Action)
+	assert.Nil(t, result.Content)
+}
@@ -0,0 +1,283 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+)
+
+const (
+	DefaultHealthCheckTimeout          = 5 * time.Second
+	DefaultHealthCheckFailureThreshold = 3
+)
+
+// HealthStatus is the health of a server as seen by the client.
+type HealthStatus string
+
+const (
+	HealthStatusHealthy   HealthStatus = "healthy"
+	HealthStatusDegraded  HealthStatus = "degraded"  // The last pings failed, but fewer than the failure threshold
+	HealthStatusUnhealthy HealthStatus = "unhealthy" // Not connected, or too many consecutive pings failed
+)
+
+// ServerHealth is the health of a server, updated by the health checker.
+type ServerHealth struct {
+	Status              HealthStatus  `json:"status"`
+	LastCheck           time.Time     `json:"last_check,omitzero"`   // Time of the last ping, zero if never pinged
+	LastSuccess         time.Time     `json:"last_success,omitzero"` // Time of the last successful ping
+	Latency             time.Duration `json:"latency_ns"`            // Round trip of the last successful ping
+	ConsecutiveFailures int           `json:"consecutive_failures"`
+	LastError           string        `json:"last_error,omitempty"`
+}
+
+// healthChecker pings every session of the client at an interval.
+type healthChecker struct {
+	interval         time.Duration
+	timeout          time.Duration
+	failureThreshold int
+
+	mu      sync.Mutex
+	servers map[string]ServerHealth
+	cancel  context.CancelFunc
+	done    sync.WaitGroup
+}
+
+// WithHealthCheck pings every connected server at interval after Start, until Close.
+// The results are reported by Health, ReadinessHandler and LivenessHandler.
+func WithHealthCheck(interval time.Duration) Option {
+	return func(c *MultiServerMCPClient) {
+		c.healthChecker.interval = interval
+	}
+}
+
+// WithHealthCheckTimeout sets the timeout of each ping. Defaults to DefaultHealthCheckTimeout,
+// or the health check interval if shorter.
+func WithHealthCheckTimeout(timeout time.Duration) Option {
+	return func(c *MultiServerMCPClient) {
+		c.healthChecker.timeout = timeout
+	}
+}
+
+// WithHealthCheckFailureThreshold sets the number of consecutive failed pings after which a
+// server is unhealthy. Defaults to DefaultHealthCheckFailureThreshold.
+func WithHealthCheckFailureThreshold(threshold int) Option {
+	return func(c *MultiServerMCPClient) {
+		c.healthChecker.failureThreshold = threshold
+	}
+}
+
+// newHealthChecker creates a health checker that is disabled until an interval is set.
+func newHealthChecker() *healthChecker {
+	return &healthChecker{
+		failureThreshold: DefaultHealthCheckFailureThreshold,
+		servers:          make(map[string]ServerHealth),
+	}
+}
+
+// startHealthCheck starts pinging the sessions in the background, if enabled.
+func (c *MultiServerMCPClient) startHealthCheck() {
+	h := c.healthChecker
+	if h.interval <= 0 {
+		return
+	}
+	if h.timeout <= 0 {
+		h.timeout = min(DefaultHealthCheckTimeout, h.interval)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.mu.Lock()
+	h.cancel = cancel
+	h.mu.Unlock()
+	h.done.Add(1)
+	go func() {
+		defer h.done.Done()
+		ticker := time.NewTicker(h.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.checkHealth(ctx)
+			}
+		}
+	}()
+}
+
+// stopHealthCheck stops the background pings, waits for the running ones to finish and
+// forgets their results.
+func (c *MultiServerMCPClient) stopHealthCheck() {
+	h := c.healthChecker
+	h.mu.Lock()
+	cancel := h.cancel
+	h.cancel = nil
+	clear(h.servers)
+	h.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		h.done.Wait()
+	}
+}
+
+// checkHealth pings all sessions concurrently and records the results.
+func (c *MultiServerMCPClient) checkHealth(ctx context.Context) {
+	c.mu.RLock()
+	sessions := make(map[string]client.MCPClient, len(c.sessions))
+	for name, session := range c.sessions {
+		sessions[name] = session
+	}
+	c.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for name, session := range sessions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pingCtx, cancel := context.WithTimeout(ctx, c.healthChecker.timeout)
+			defer cancel()
+			start := time.Now()
+			err := session.Ping(pingCtx)
+			if ctx.Err() != nil {
+				return // Stopped by Close, not a failure of the server
+			}
+			c.recordPing(name, start, time.Since(start), err)
+		}()
+	}
+	wg.Wait()
+}
+
+// recordPing updates the health of serverName with the result of a ping.
+func (c *MultiServerMCPClient) recordPing(serverName string, at time.Time, latency time.Duration, err error) {
+	h := c.healthChecker
+	logger := c.loggerFor(serverName)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	health := h.servers[serverName]
+	previous := h.status(health)
+	health.LastCheck = at
+	if err != nil {
+		health.ConsecutiveFailures++
+		health.LastError = err.Error()
+	} else {
+		health.ConsecutiveFailures = 0
+		health.LastError = ""
+		health.LastSuccess = at
+		health.Latency = latency
+	}
+	h.servers[serverName] = health
+
+	switch status := h.status(health); {
+	case status == previous:
+	case status == HealthStatusUnhealthy:
+		logger.Warn("Health check server became unhealthy", "consecutive_failures", health.ConsecutiveFailures, "error", err)
+	case status == HealthStatusHealthy:
+		logger.Info("Health check server recovered")
+	default:
+		logger.Debug("Health check ping failed", "consecutive_failures", health.ConsecutiveFailures, "error", err)
+	}
+}
+
+// resetHealth forgets the ping results of serverName, after connecting to it again.
+func (c *MultiServerMCPClient) resetHealth(serverName string) {
+	h := c.healthChecker
+	h.mu.Lock()
+	delete(h.servers, serverName)
+	h.mu.Unlock()
+}
+
+// status returns the status of a connected server from its ping results.
+func (h *healthChecker) status(health ServerHealth) HealthStatus {
+	switch {
+	case health.ConsecutiveFailures >= max(h.failureThreshold, 1):
+		return HealthStatusUnhealthy
+	case health.ConsecutiveFailures > 0:
+		return HealthStatusDegraded
+	default:
+		return HealthStatusHealthy
+	}
+}
+
+// Health returns the health of every configured server. Connected servers are healthy until
+// pings fail, which requires WithHealthCheck; servers without a session are unhealthy.
+func (c *MultiServerMCPClient) Health() map[string]ServerHealth {
+	c.mu.RLock()
+	connected := make(map[string]bool, len(c.sessions))
+	for name := range c.sessions {
+		connected[name] = true
+	}
+	names := make([]string, 0, len(c.connections))
+	for name := range c.connections {
+		names = append(names, name)
+	}
+	c.mu.RUnlock()
+
+	h := c.healthChecker
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	result := make(map[string]ServerHealth, len(names))
+	for _, name := range names {
+		health := h.servers[name]
+		if connected[name] {
+			health.Status = h.status(health)
+		} else {
+			health.Status = HealthStatusUnhealthy
+			health.LastError = "no active session"
+		}
+		result[name] = health
+	}
+	return result
+}
+
+// healthResponse is the body written by the health handlers.
+type healthResponse struct {
+	Status  HealthStatus            `json:"status"`
+	Servers map[string]ServerHealth `json:"servers"`
+}
+
+// ReadinessHandler returns an http.Handler for readiness probes. It responds with 200 if no
+// server is unhealthy and 503 otherwise, with the health of every server as JSON.
+func (c *MultiServerMCPClient) ReadinessHandler() http.Handler {
+	return c.healthHandler(func(unhealthy, total int) bool { return unhealthy == 0 })
+}
+
+// LivenessHandler returns an http.Handler for liveness probes. It responds with 503 only if
+// every server is unhealthy, so that a single failing server does not restart the service.
+func (c *MultiServerMCPClient) LivenessHandler() http.Handler {
+	return c.healthHandler(func(unhealthy, total int) bool { return total == 0 || unhealthy < total })
+}
+
+// healthHandler reports the health of the servers, with 200 if ok accepts the number of
+// unhealthy servers and 503 otherwise.
+func (c *MultiServerMCPClient) healthHandler(ok func(unhealthy, total int) bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		servers := c.Health()
+		unhealthy, degraded := 0, 0
+		for _, health := range servers {
+			switch health.Status {
+			case HealthStatusUnhealthy:
+				unhealthy++
+			case HealthStatusDegraded:
+				degraded++
+			}
+		}
+
+		response := healthResponse{Status: HealthStatusHealthy, Servers: servers}
+		statusCode := http.StatusOK
+		switch {
+		case !ok(unhealthy, len(servers)):
+			response.Status = HealthStatusUnhealthy
+			statusCode = http.StatusServiceUnavailable
+		case unhealthy > 0 || degraded > 0:
+			response.Status = HealthStatusDegraded
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_ = json.NewEncoder(w).Encode(response)
+	})
+}
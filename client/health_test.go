@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	. "github.com/ovechkin-dm/mockio/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiServerMCPClient_CheckHealth(t *testing.T) {
+	SetUp(t)
+
+	healthy := Mock[MockMCPClientInternal]()
+	failing := Mock[MockMCPClientInternal]()
+	When(healthy.Ping(Any[context.Context]())).ThenReturn(nil)
+	When(failing.Ping(Any[context.Context]())).ThenReturn(errors.New("connection reset"))
+
+	conns := map[string]ConnectionConfig{"a": SSEConnection{}, "b": SSEConnection{}, "c": SSEConnection{}}
+	msc := NewMultiServerMCPClient(conns, mcp.Implementation{}, mcp.ClientCapabilities{},
+		WithHealthCheck(time.Hour),
+		WithHealthCheckFailureThreshold(2),
+	)
+	msc.healthChecker.timeout = time.Second
+	msc.sessions["a"] = healthy
+	msc.sessions["b"] = failing
+
+	msc.checkHealth(context.Background())
+
+	health := msc.Health()
+	require.Len(t, health, 3)
+	assert.Equal(t, HealthStatusHealthy, health["a"].Status)
+	assert.False(t, health["a"].LastSuccess.IsZero())
+	assert.Equal(t, HealthStatusDegraded, health["b"].Status)
+	assert.Equal(t, 1, health["b"].ConsecutiveFailures)
+	assert.Equal(t, "connection reset", health["b"].LastError)
+	assert.Equal(t, HealthStatusUnhealthy, health["c"].Status)
+	assert.Equal(t, "no active session", health["c"].LastError)
+
+	msc.checkHealth(context.Background())
+
+	health = msc.Health()
+	assert.Equal(t, HealthStatusUnhealthy, health["b"].Status)
+	assert.Equal(t, 2, health["b"].ConsecutiveFailures)
+	assert.True(t, health["b"].LastSuccess.IsZero())
+}
+
+func TestMultiServerMCPClient_HealthHandlers(t *testing.T) {
+	SetUp(t)
+
+	conns := map[string]ConnectionConfig{"a": SSEConnection{}, "b": SSEConnection{}}
+	msc := NewMultiServerMCPClient(conns, mcp.Implementation{}, mcp.ClientCapabilities{})
+	msc.sessions["a"] = Mock[MockMCPClientInternal]()
+
+	tests := []struct {
+		name           string
+		handler        http.Handler
+		expectedCode   int
+		expectedStatus HealthStatus
+	}{
+		{"readiness", msc.ReadinessHandler(), http.StatusServiceUnavailable, HealthStatusUnhealthy},
+		{"liveness", msc.LivenessHandler(), http.StatusOK, HealthStatusDegraded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			tt.handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			var response healthResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedStatus, response.Status)
+			assert.Equal(t, HealthStatusHealthy, response.Servers["a"].Status)
+			assert.Equal(t, HealthStatusUnhealthy, response.Servers["b"].Status)
+		})
+	}
+}
+
+func TestMultiServerMCPClient_HealthCheck_Background(t *testing.T) {
+	SetUp(t)
+
+	mockClient := Mock[MockMCPClientInternal]()
+	When(mockClient.Ping(Any[context.Context]())).ThenReturn(nil)
+	When(mockClient.Close()).ThenReturn(nil)
+
+	conns := map[string]ConnectionConfig{"a": SSEConnection{}}
+	msc := NewMultiServerMCPClient(conns, mcp.Implementation{}, mcp.ClientCapabilities{}, WithHealthCheck(10*time.Millisecond))
+	msc.sessions["a"] = mockClient
+
+	msc.startHealthCheck()
+	require.Eventually(t, func() bool { return !msc.Health()["a"].LastCheck.IsZero() }, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, msc.Close())
+	assert.Nil(t, msc.healthChecker.cancel)
+	assert.Equal(t, HealthStatusUnhealthy, msc.Health()["a"].Status)
+}
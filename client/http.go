@@ -0,0 +1,203 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/auth"
+)
+
+// TLSConfig defines the TLS settings of an HTTP-based connection.
+type TLSConfig struct {
+	CAFile     string `json:"ca_file,omitempty"`     // PEM bundle of the CAs trusted to verify the server, instead of the system roots
+	CertFile   string `json:"cert_file,omitempty"`   // PEM client certificate for mutual TLS
+	KeyFile    string `json:"key_file,omitempty"`    // PEM private key of the client certificate
+	ServerName string `json:"server_name,omitempty"` // Name to verify the server certificate against, defaults to the host of the URL
+	MinVersion uint16 `json:"min_version,omitempty"` // Minimum TLS version such as tls.VersionTLS13, defaults to TLS 1.2
+}
+
+// tlsConfig builds the crypto/tls configuration of c.
+func (c *TLSConfig) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName: c.ServerName,
+		MinVersion: c.MinVersion,
+	}
+	if config.MinVersion == 0 {
+		config.MinVersion = tls.VersionTLS12
+	}
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", c.CAFile)
+		}
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return config, nil
+}
+
+// sseHTTPClient returns the HTTP client of an SSE connection. It enforces Timeout on every
+// request and SSEReadTimeout on the event stream, adds HeaderCredentials to every request, and
+// authorizes requests if Auth is set.
+func sseHTTPClient(config SSEConnection) (*http.Client, error) {
+	httpClient := &http.Client{}
+	switch {
+	case config.HTTPClient != nil && (config.TLS != nil || config.ProxyURL != ""):
+		return nil, errors.New("TLS and ProxyURL cannot be combined with HTTPClient, configure its transport instead")
+	case config.HTTPClient != nil && config.HTTPClient.Timeout != 0:
+		return nil, errors.New("HTTPClient must not have a Timeout, as it would end the SSE stream, set Timeout and SSEReadTimeout instead")
+	case config.HTTPClient != nil:
+		*httpClient = *config.HTTPClient
+	default:
+		base := http.DefaultTransport.(*http.Transport).Clone()
+		if config.TLS != nil {
+			tlsConfig, err := config.TLS.tlsConfig()
+			if err != nil {
+				return nil, err
+			}
+			base.TLSClientConfig = tlsConfig
+		}
+		if config.ProxyURL != "" {
+			proxyURL, err := url.Parse(config.ProxyURL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid proxy URL: %w", err)
+			}
+			base.Proxy = http.ProxyURL(proxyURL)
+		}
+		httpClient.Transport = base
+	}
+
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	httpClient.Transport = &timeoutTransport{
+		base:        base,
+		timeout:     config.Timeout,
+		readTimeout: config.SSEReadTimeout,
+	}
+	if len(config.HeaderCredentials) > 0 {
+		httpClient.Transport = &credentialTransport{base: httpClient.Transport, credentials: config.HeaderCredentials}
+	}
+	if config.Auth != nil {
+		// Authorizes every request, refreshing the token when the server rejects it
+		httpClient = auth.NewHTTPClient(config.Auth, httpClient)
+	}
+	return httpClient, nil
+}
+
+// timeoutTransport fails requests whose response headers do not arrive within timeout, and
+// closes event streams that receive no data for readTimeout. Unlike http.Client.Timeout, it
+// does not limit how long a response body, such as an event stream, may be read.
+type timeoutTransport struct {
+	base        http.RoundTripper
+	timeout     time.Duration
+	readTimeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.wrapStream(t.base.RoundTrip(req))
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	timer := time.AfterFunc(t.timeout, cancel)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if !timer.Stop() {
+		cancel()
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return nil, fmt.Errorf("%s %s: no response within %s", req.Method, req.URL.Redacted(), t.timeout)
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The body is read with the context, so it is released when the body is closed
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return t.wrapStream(resp, nil)
+}
+
+// wrapStream applies the read timeout to the body of an event stream response.
+func (t *timeoutTransport) wrapStream(resp *http.Response, err error) (*http.Response, error) {
+	if err != nil || t.readTimeout <= 0 || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		return resp, err
+	}
+	resp.Body = newIdleTimeoutBody(resp.Body, t.readTimeout)
+	return resp, nil
+}
+
+// cancelOnClose cancels the context of a request when its response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// errSSEReadTimeout is returned by reads of an event stream that was idle for too long.
+var errSSEReadTimeout = errors.New("SSE read timeout: no data received")
+
+// idleTimeoutBody closes a response body that receives no data for timeout, which makes the
+// pending read fail.
+type idleTimeoutBody struct {
+	body    io.ReadCloser
+	timeout time.Duration
+	timer   *time.Timer
+
+	mu       sync.Mutex
+	timedOut bool
+}
+
+func newIdleTimeoutBody(body io.ReadCloser, timeout time.Duration) *idleTimeoutBody {
+	b := &idleTimeoutBody{body: body, timeout: timeout}
+	b.timer = time.AfterFunc(timeout, func() {
+		b.mu.Lock()
+		b.timedOut = true
+		b.mu.Unlock()
+		_ = body.Close()
+	})
+	return b
+}
+
+func (b *idleTimeoutBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.mu.Lock()
+	timedOut := b.timedOut
+	b.mu.Unlock()
+	if timedOut {
+		return n, errSSEReadTimeout
+	}
+	if n > 0 {
+		b.timer.Reset(b.timeout)
+	}
+	return n, err
+}
+
+func (b *idleTimeoutBody) Close() error {
+	b.timer.Stop()
+	return b.body.Close()
+}
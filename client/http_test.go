@@ -0,0 +1,297 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/sampling"
+)
+
+func newEchoSSEServer() *server.SSEServer {
+	mcpServer := server.NewMCPServer("echo", "1.0.0")
+	mcpServer.AddTool(mcp.NewTool("echo", mcp.WithString("text", mcp.Required())),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(request.GetString("text", "")), nil
+		})
+	return server.NewSSEServer(mcpServer)
+}
+
+func TestMultiServerMCPClient_Start_SSESessionOutlivesStart(t *testing.T) {
+	httpServer := httptest.NewServer(newEchoSSEServer())
+	defer httpServer.Close()
+
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{
+		"echo": SSEConnection{URL: httpServer.URL + "/sse", Timeout: 100 * time.Millisecond},
+	}, mcp.Implementation{}, mcp.ClientCapabilities{})
+	require.NoError(t, msc.Start(context.Background()))
+	defer msc.Close()
+
+	// Outlive the connection timeout, which used to end the event stream
+	time.Sleep(200 * time.Millisecond)
+	loadedTools := msc.GetTools()
+	require.Len(t, loadedTools, 1)
+	output, err := loadedTools[0].Call(context.Background(), `{"text": "still connected"}`)
+
+	require.NoError(t, err)
+	assert.Equal(t, "still connected", output)
+}
+
+// writePEM writes a PEM block of the given type to a new file in dir.
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+// newClientCertificate creates a self-signed client certificate and its key in dir.
+func newClientCertificate(t *testing.T, dir string) (certFile, keyFile string, pool *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "mcp-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	pool = x509.NewCertPool()
+	pool.AddCert(certificate)
+	return writePEM(t, dir, "client.pem", "CERTIFICATE", der), writePEM(t, dir, "client-key.pem", "EC PRIVATE KEY", keyDER), pool
+}
+
+func TestMultiServerMCPClient_Reconnect_Concurrent(t *testing.T) {
+	httpServer := httptest.NewServer(newEchoSSEServer())
+	defer httpServer.Close()
+
+	recorder := &FakeRecorder{}
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{
+		"echo": SSEConnection{URL: httpServer.URL + "/sse"},
+	}, mcp.Implementation{}, mcp.ClientCapabilities{}, WithMetrics(recorder))
+	require.NoError(t, msc.Start(context.Background()))
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, msc.Reconnect(context.Background(), "echo"))
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, msc.GetTools(), 1)
+	require.NoError(t, msc.Close())
+
+	counts := map[string]int{}
+	recorder.mu.Lock()
+	for _, event := range recorder.events {
+		counts[event]++
+	}
+	recorder.mu.Unlock()
+	assert.Equal(t, map[string]int{"opened echo": 6, "closed echo": 6, "reconnect echo": 5}, counts,
+		"Every session opened by a reconnect should be closed")
+}
+
+func TestMultiServerMCPClient_Start_SSECapabilities(t *testing.T) {
+	capabilities := make(chan mcp.ClientCapabilities, 1)
+	hooks := &server.Hooks{}
+	hooks.AddBeforeInitialize(func(_ context.Context, _ any, request *mcp.InitializeRequest) {
+		capabilities <- request.Params.Capabilities
+	})
+	httpServer := httptest.NewServer(server.NewSSEServer(server.NewMCPServer("echo", "1.0.0", server.WithToolCapabilities(false), server.WithHooks(hooks))))
+	defer httpServer.Close()
+
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{
+		"echo": SSEConnection{URL: httpServer.URL + "/sse"},
+	}, mcp.Implementation{}, mcp.ClientCapabilities{Experimental: map[string]any{"custom": true}},
+		WithSamplingHandler(sampling.NewHandler(nil)),
+		WithRoots(mcp.Root{URI: "file:///workspace"}),
+		WithElicitationHandler(nil),
+	)
+	require.NoError(t, msc.Start(context.Background()))
+	defer msc.Close()
+
+	assert.Equal(t, mcp.ClientCapabilities{Experimental: map[string]any{"custom": true}}, <-capabilities,
+		"Requests from SSE servers cannot be answered, so they should not be advertised")
+}
+
+func TestMultiServerMCPClient_ConnectViaSSE_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, clientCAs := newClientCertificate(t, dir)
+
+	httpServer := httptest.NewUnstartedServer(newEchoSSEServer())
+	httpServer.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	httpServer.StartTLS()
+	defer httpServer.Close()
+	caFile := writePEM(t, dir, "ca.pem", "CERTIFICATE", httpServer.Certificate().Raw)
+
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{})
+
+	_, err := msc.connectToServerViaSSE(context.Background(), "tls", SSEConnection{
+		URL: httpServer.URL + "/sse",
+		TLS: &TLSConfig{CAFile: caFile},
+	})
+	require.Error(t, err, "Connecting without a client certificate should fail")
+
+	session, err := msc.connectToServerViaSSE(context.Background(), "tls", SSEConnection{
+		URL: httpServer.URL + "/sse",
+		TLS: &TLSConfig{CAFile: caFile, CertFile: certFile, KeyFile: keyFile, ServerName: "example.com", MinVersion: tls.VersionTLS13},
+	})
+	require.NoError(t, err)
+	assert.NoError(t, session.Close())
+}
+
+func TestMultiServerMCPClient_ConnectViaSSE_Proxy(t *testing.T) {
+	sseServer := newEchoSSEServer()
+	var mu sync.Mutex
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		proxied = append(proxied, r.Method+" "+r.URL.String())
+		mu.Unlock()
+		sseServer.ServeHTTP(w, r) // Stands in for the server behind the proxy
+	}))
+	defer proxy.Close()
+
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{})
+	session, err := msc.connectToServerViaSSE(context.Background(), "proxied", SSEConnection{
+		URL:      "http://mcp.invalid/sse",
+		ProxyURL: proxy.URL,
+	})
+	require.NoError(t, err)
+	defer session.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, proxied)
+	assert.Equal(t, "GET http://mcp.invalid/sse", proxied[0])
+}
+
+func TestMultiServerMCPClient_ConnectViaSSE_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer httpServer.Close()
+	defer close(release)
+
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{})
+	start := time.Now()
+	_, err := msc.connectToServerViaSSE(context.Background(), "slow", SSEConnection{
+		URL:     httpServer.URL + "/sse",
+		Timeout: 50 * time.Millisecond,
+	})
+
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestSSEHTTPClient_InvalidConfig(t *testing.T) {
+	_, err := sseHTTPClient(SSEConnection{HTTPClient: &http.Client{}, ProxyURL: "http://proxy.example.com"})
+	require.ErrorContains(t, err, "cannot be combined with HTTPClient")
+
+	_, err = sseHTTPClient(SSEConnection{TLS: &TLSConfig{CAFile: filepath.Join(t.TempDir(), "missing.pem")}})
+	require.ErrorContains(t, err, "failed to read CA file")
+
+	_, err = sseHTTPClient(SSEConnection{HTTPClient: &http.Client{Timeout: 30 * time.Second}})
+	require.ErrorContains(t, err, "HTTPClient must not have a Timeout")
+}
+
+func TestMultiServerMCPClient_ConnectViaSSE_HTTPClient(t *testing.T) {
+	httpServer := httptest.NewServer(newEchoSSEServer())
+	defer httpServer.Close()
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{})
+
+	_, err := msc.connectToServerViaSSE(context.Background(), "echo", SSEConnection{
+		URL:        httpServer.URL + "/sse",
+		HTTPClient: &http.Client{Timeout: time.Minute},
+	})
+	require.ErrorContains(t, err, "failed to configure HTTP client for echo")
+
+	var mu sync.Mutex
+	requests := 0
+	custom := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+	session, err := msc.connectToServerViaSSE(context.Background(), "echo", SSEConnection{URL: httpServer.URL + "/sse", HTTPClient: custom})
+	require.NoError(t, err)
+	assert.NoError(t, session.Close())
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Positive(t, requests, "Requests should go through the transport of the custom client")
+}
+
+// roundTripFunc adapts a function to an http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestIdleTimeoutBody(t *testing.T) {
+	reader, writer := io.Pipe()
+	body := newIdleTimeoutBody(reader, 50*time.Millisecond)
+	defer body.Close()
+
+	go func() { _, _ = writer.Write([]byte("event: endpoint\n")) }()
+	buf := make([]byte, 64)
+	n, err := body.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "event: endpoint\n", string(buf[:n]))
+
+	// No more data arrives, so the pending read fails once the stream is idle for too long
+	_, err = body.Read(buf)
+	require.ErrorIs(t, err, errSSEReadTimeout)
+}
+
+func TestTimeoutTransport_NoReadTimeoutByDefault(t *testing.T) {
+	body := io.NopCloser(strings.NewReader("event: endpoint\n"))
+	stream := func(*http.Request) (*http.Response, error) {
+		return &http.Response{Header: http.Header{"Content-Type": {"text/event-stream"}}, Body: body}, nil
+	}
+
+	httpClient, err := sseHTTPClient(SSEConnection{HTTPClient: &http.Client{Transport: roundTripFunc(stream)}})
+	require.NoError(t, err)
+	resp, err := httpClient.Transport.RoundTrip(httptest.NewRequest(http.MethodGet, "http://example.com/sse", nil))
+	require.NoError(t, err)
+	assert.Equal(t, body, resp.Body, "Quiet event streams are kept open unless SSEReadTimeout is set")
+
+	httpClient, err = sseHTTPClient(SSEConnection{SSEReadTimeout: time.Minute, HTTPClient: &http.Client{Transport: roundTripFunc(stream)}})
+	require.NoError(t, err)
+	resp, err = httpClient.Transport.RoundTrip(httptest.NewRequest(http.MethodGet, "http://example.com/sse", nil))
+	require.NoError(t, err)
+	assert.IsType(t, &idleTimeoutBody{}, resp.Body)
+	require.NoError(t, resp.Body.Close())
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// methodNotificationMessage is the JSON-RPC method used by servers to send log messages.
+const methodNotificationMessage = "notifications/message"
+
+// Additional slog levels for MCP (syslog) severities that have no slog equivalent.
+const (
+	LevelNotice    = slog.Level(2)
+	LevelCritical  = slog.Level(12)
+	LevelAlert     = slog.Level(16)
+	LevelEmergency = slog.Level(20)
+)
+
+// ServerLog is a log message sent by a server.
+type ServerLog struct {
+	ServerName string
+	Level      mcp.LoggingLevel
+	Logger     string // Name of the logger on the server, empty if not set
+	Data       any    // Usually a string, or any JSON value
+}
+
+// SlogLevel maps an MCP logging level to the corresponding slog level.
+// Unknown levels are mapped to slog.LevelInfo.
+func SlogLevel(level mcp.LoggingLevel) slog.Level {
+	switch level {
+	case mcp.LoggingLevelDebug:
+		return slog.LevelDebug
+	case mcp.LoggingLevelInfo:
+		return slog.LevelInfo
+	case mcp.LoggingLevelNotice:
+		return LevelNotice
+	case mcp.LoggingLevelWarning:
+		return slog.LevelWarn
+	case mcp.LoggingLevelError:
+		return slog.LevelError
+	case mcp.LoggingLevelCritical:
+		return LevelCritical
+	case mcp.LoggingLevelAlert:
+		return LevelAlert
+	case mcp.LoggingLevelEmergency:
+		return LevelEmergency
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// setLogLevel asks the server to send log messages at level and above.
+// Servers that do not advertise the logging capability are skipped.
+func (c *MultiServerMCPClient) setLogLevel(ctx context.Context, serverName string, session client.MCPClient, capabilities mcp.ServerCapabilities, level mcp.LoggingLevel) error {
+	if level == "" {
+		return nil
+	}
+	if capabilities.Logging == nil {
+		c.loggerFor(serverName).Debug("setLogLevel server does not support logging, skipping", "level", level)
+		return nil
+	}
+
+	request := mcp.SetLevelRequest{}
+	request.Params.Level = level
+	if err := session.SetLevel(ctx, request); err != nil {
+		return fmt.Errorf("failed to set log level for %s: %w", serverName, err)
+	}
+	c.loggerFor(serverName).Debug("setLogLevel log level set", "level", level)
+	return nil
+}
+
+// handleLogMessage forwards a notifications/message log entry from a server to the server logger.
+func (c *MultiServerMCPClient) handleLogMessage(serverName string, notification mcp.JSONRPCNotification) {
+	raw, err := json.Marshal(notification.Params)
+	if err != nil {
+		c.loggerFor(serverName).Debug("handleLogMessage failed to encode notification params", "error", err)
+		return
+	}
+	var message mcp.LoggingMessageNotification
+	if err := json.Unmarshal(raw, &message.Params); err != nil {
+		c.loggerFor(serverName).Debug("handleLogMessage failed to decode log message", "error", err)
+		return
+	}
+
+	serverLogger := c.serverLogger
+	if serverLogger == nil {
+		serverLogger = c.logger
+	}
+
+	attrs := []slog.Attr{slog.String("server_name", serverName)}
+	if message.Params.Logger != "" {
+		attrs = append(attrs, slog.String("logger", message.Params.Logger))
+	}
+
+	var msg string
+	switch data := message.Params.Data.(type) {
+	case string:
+		msg = data
+	default:
+		msg = "MCP server log message"
+		attrs = append(attrs, slog.Any("data", data))
+	}
+
+	serverLogger.LogAttrs(context.Background(), SlogLevel(message.Params.Level), msg, attrs...)
+
+	if handler, ok := c.mcpCallbacks(); ok {
+		handler.HandleServerLog(context.Background(), ServerLog{
+			ServerName: serverName,
+			Level:      message.Params.Level,
+			Logger:     message.Params.Logger,
+			Data:       message.Params.Data,
+		})
+	}
+}
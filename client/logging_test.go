@@ -0,0 +1,113 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	. "github.com/ovechkin-dm/mockio/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func logMessageNotification(level mcp.LoggingLevel, logger string, data any) mcp.JSONRPCNotification {
+	notification := mcp.JSONRPCNotification{JSONRPC: mcp.JSONRPC_VERSION}
+	notification.Method = methodNotificationMessage
+	notification.Params.AdditionalFields = map[string]any{
+		"level":  level,
+		"logger": logger,
+		"data":   data,
+	}
+	return notification
+}
+
+func TestSlogLevel(t *testing.T) {
+	tests := []struct {
+		level    mcp.LoggingLevel
+		expected slog.Level
+	}{
+		{mcp.LoggingLevelDebug, slog.LevelDebug},
+		{mcp.LoggingLevelInfo, slog.LevelInfo},
+		{mcp.LoggingLevelNotice, LevelNotice},
+		{mcp.LoggingLevelWarning, slog.LevelWarn},
+		{mcp.LoggingLevelError, slog.LevelError},
+		{mcp.LoggingLevelCritical, LevelCritical},
+		{mcp.LoggingLevelAlert, LevelAlert},
+		{mcp.LoggingLevelEmergency, LevelEmergency},
+		{"unknown", slog.LevelInfo},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.level), func(t *testing.T) {
+			assert.Equal(t, tt.expected, SlogLevel(tt.level))
+		})
+	}
+}
+
+func TestMultiServerMCPClient_HandleLogMessage(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{}, WithServerLogger(logger))
+
+	handler := msc.notificationHandler("indexer", nil, nil)
+	handler(logMessageNotification(mcp.LoggingLevelWarning, "walker", "skipping large file"))
+	handler(logMessageNotification(mcp.LoggingLevelCritical, "", map[string]any{"files": 3}))
+
+	decoder := json.NewDecoder(&buf)
+
+	var first map[string]any
+	require.NoError(t, decoder.Decode(&first))
+	assert.Equal(t, "WARN", first["level"])
+	assert.Equal(t, "skipping large file", first["msg"])
+	assert.Equal(t, "indexer", first["server_name"])
+	assert.Equal(t, "walker", first["logger"])
+
+	var second map[string]any
+	require.NoError(t, decoder.Decode(&second))
+	assert.Equal(t, "ERROR+4", second["level"])
+	assert.Equal(t, "indexer", second["server_name"])
+	assert.NotContains(t, second, "logger")
+	assert.Equal(t, map[string]any{"files": float64(3)}, second["data"])
+}
+
+func TestMultiServerMCPClient_InitializeAndLoad_SetsLogLevel(t *testing.T) {
+	SetUp(t)
+
+	mockClient := Mock[MockMCPClientInternal]()
+	initResult := &mcp.InitializeResult{}
+	initResult.Capabilities.Logging = &struct{}{}
+	expectedRequest := mcp.SetLevelRequest{}
+	expectedRequest.Params.Level = mcp.LoggingLevelWarning
+
+	When(mockClient.Initialize(Any[context.Context](), Any[mcp.InitializeRequest]())).ThenReturn(initResult, nil)
+	When(mockClient.SetLevel(Any[context.Context](), Equal(expectedRequest))).ThenReturn(errors.New("not supported"))
+	When(mockClient.ListTools(Any[context.Context](), Any[mcp.ListToolsRequest]())).ThenReturn(&mcp.ListToolsResult{}, nil)
+
+	conns := map[string]ConnectionConfig{"server1": StdioConnection{LogLevel: mcp.LoggingLevelWarning}}
+	msc := NewMultiServerMCPClient(conns, mcp.Implementation{}, mcp.ClientCapabilities{})
+
+	err := msc.initializeSessionAndLoadTools(context.Background(), "server1", mockClient)
+
+	require.NoError(t, err, "A failing SetLevel should not fail initialization")
+	Verify(mockClient, Once()).SetLevel(Any[context.Context](), Equal(expectedRequest))
+}
+
+func TestMultiServerMCPClient_InitializeAndLoad_SkipsLogLevelWithoutCapability(t *testing.T) {
+	SetUp(t)
+
+	mockClient := Mock[MockMCPClientInternal]()
+	When(mockClient.Initialize(Any[context.Context](), Any[mcp.InitializeRequest]())).ThenReturn(&mcp.InitializeResult{}, nil)
+	When(mockClient.ListTools(Any[context.Context](), Any[mcp.ListToolsRequest]())).ThenReturn(&mcp.ListToolsResult{}, nil)
+
+	conns := map[string]ConnectionConfig{"server1": SSEConnection{LogLevel: mcp.LoggingLevelDebug}}
+	msc := NewMultiServerMCPClient(conns, mcp.Implementation{}, mcp.ClientCapabilities{})
+
+	err := msc.initializeSessionAndLoadTools(context.Background(), "server1", mockClient)
+
+	require.NoError(t, err)
+	Verify(mockClient, Never()).SetLevel(Any[context.Context](), Any[mcp.SetLevelRequest]())
+}
@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// methodNotificationPromptsListChanged is the JSON-RPC method used by servers to announce
+// that their list of prompts changed.
+const methodNotificationPromptsListChanged = "notifications/prompts/list_changed"
+
+// ErrPromptNotFound is returned by FindPrompt when no connected server offers the prompt.
+var ErrPromptNotFound = errors.New("prompt not found")
+
+// ServerPrompt is a prompt offered by a connected server.
+type ServerPrompt struct {
+	mcp.Prompt
+	ServerName string
+}
+
+// AmbiguousPromptError is returned by FindPrompt when several servers offer a prompt with the
+// same name. Use GetPrompt with one of ServerNames to pick one.
+type AmbiguousPromptError struct {
+	Name        string
+	ServerNames []string
+}
+
+func (e *AmbiguousPromptError) Error() string {
+	return fmt.Sprintf("prompt %s is offered by several servers: %s", e.Name, strings.Join(e.ServerNames, ", "))
+}
+
+// serverCapabilitiesGetter is implemented by sessions that remember the capabilities
+// returned by initialize.
+type serverCapabilitiesGetter interface {
+	GetServerCapabilities() mcp.ServerCapabilities
+}
+
+// WithPromptCache caches the prompts listed by ListPrompts for each server until the server
+// sends notifications/prompts/list_changed.
+func WithPromptCache() Option {
+	return func(c *MultiServerMCPClient) {
+		c.promptCache = make(map[string][]mcp.Prompt)
+	}
+}
+
+// ListPrompts returns the prompts of all connected servers, ordered by server name.
+// Servers that do not advertise the prompts capability are skipped. If listing fails for
+// some servers, the prompts of the other servers are returned with the joined errors.
+func (c *MultiServerMCPClient) ListPrompts(ctx context.Context) ([]ServerPrompt, error) {
+	c.mu.RLock()
+	serverNames := make([]string, 0, len(c.sessions))
+	for name := range c.sessions {
+		serverNames = append(serverNames, name)
+	}
+	c.mu.RUnlock()
+	slices.Sort(serverNames)
+
+	var serverPrompts []ServerPrompt
+	var errs []error
+	for _, serverName := range serverNames {
+		prompts, err := c.listServerPrompts(ctx, serverName)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list prompts of %s: %w", serverName, err))
+			continue
+		}
+		for _, prompt := range prompts {
+			serverPrompts = append(serverPrompts, ServerPrompt{Prompt: prompt, ServerName: serverName})
+		}
+	}
+	return serverPrompts, errors.Join(errs...)
+}
+
+// FindPrompt looks up a prompt by name across all connected servers. It returns
+// ErrPromptNotFound if no server offers it and an *AmbiguousPromptError if several do.
+func (c *MultiServerMCPClient) FindPrompt(ctx context.Context, name string) (ServerPrompt, error) {
+	serverPrompts, err := c.ListPrompts(ctx)
+	var matches []ServerPrompt
+	for _, serverPrompt := range serverPrompts {
+		if serverPrompt.Name == name {
+			matches = append(matches, serverPrompt)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		if err != nil {
+			return ServerPrompt{}, fmt.Errorf("%w: %s: %w", ErrPromptNotFound, name, err)
+		}
+		return ServerPrompt{}, fmt.Errorf("%w: %s", ErrPromptNotFound, name)
+	case 1:
+		return matches[0], nil
+	default:
+		ambiguous := &AmbiguousPromptError{Name: name}
+		for _, match := range matches {
+			ambiguous.ServerNames = append(ambiguous.ServerNames, match.ServerName)
+		}
+		return ServerPrompt{}, ambiguous
+	}
+}
+
+// listServerPrompts returns the prompts of serverName, from the cache if enabled.
+func (c *MultiServerMCPClient) listServerPrompts(ctx context.Context, serverName string) ([]mcp.Prompt, error) {
+	c.mu.RLock()
+	session, ok := c.sessions[serverName]
+	cached, isCached := c.promptCache[serverName]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no active session for server: %s", serverName)
+	}
+	if isCached {
+		return cached, nil
+	}
+
+	if getter, ok := session.(serverCapabilitiesGetter); ok && getter.GetServerCapabilities().Prompts == nil {
+		c.loggerFor(serverName).Debug("listServerPrompts server does not support prompts, skipping")
+		return nil, nil
+	}
+
+	prompts, err := listAllPrompts(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if c.promptCache != nil && c.sessions[serverName] == session {
+		c.promptCache[serverName] = prompts
+	}
+	c.mu.Unlock()
+	return prompts, nil
+}
+
+// listAllPrompts lists the prompts of session, following pagination cursors.
+func listAllPrompts(ctx context.Context, session client.MCPClient) ([]mcp.Prompt, error) {
+	var prompts []mcp.Prompt
+	request := mcp.ListPromptsRequest{}
+	for {
+		result, err := session.ListPromptsByPage(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		prompts = append(prompts, result.Prompts...)
+		if result.NextCursor == "" {
+			return prompts, nil
+		}
+		request.Params.Cursor = result.NextCursor
+	}
+}
+
+// invalidatePrompts drops the cached prompts of serverName.
+func (c *MultiServerMCPClient) invalidatePrompts(serverName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.promptCache != nil {
+		c.loggerFor(serverName).Debug("invalidatePrompts prompts list changed")
+		delete(c.promptCache, serverName)
+	}
+}
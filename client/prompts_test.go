@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	. "github.com/ovechkin-dm/mockio/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MockCapabilitiesMCPClient interface {
+	MockMCPClientInternal
+	GetServerCapabilities() mcp.ServerCapabilities
+}
+
+func listPromptsRequest(cursor mcp.Cursor) mcp.ListPromptsRequest {
+	request := mcp.ListPromptsRequest{}
+	request.Params.Cursor = cursor
+	return request
+}
+
+func promptsListChangedNotification() mcp.JSONRPCNotification {
+	notification := mcp.JSONRPCNotification{JSONRPC: mcp.JSONRPC_VERSION}
+	notification.Method = methodNotificationPromptsListChanged
+	return notification
+}
+
+func TestMultiServerMCPClient_ListPrompts(t *testing.T) {
+	SetUp(t)
+
+	mathClient := Mock[MockMCPClientInternal]()
+	weatherClient := Mock[MockMCPClientInternal]()
+	toolsOnlyClient := Mock[MockCapabilitiesMCPClient]()
+	When(mathClient.ListPromptsByPage(Any[context.Context](), Equal(listPromptsRequest("")))).
+		ThenReturn(&mcp.ListPromptsResult{
+			Prompts:         []mcp.Prompt{mcp.NewPrompt("solve", mcp.WithPromptDescription("Solves an equation"), mcp.WithArgument("equation", mcp.RequiredArgument()))},
+			PaginatedResult: mcp.PaginatedResult{NextCursor: "page2"},
+		}, nil)
+	When(mathClient.ListPromptsByPage(Any[context.Context](), Equal(listPromptsRequest("page2")))).
+		ThenReturn(&mcp.ListPromptsResult{Prompts: []mcp.Prompt{mcp.NewPrompt("explain")}}, nil)
+	When(weatherClient.ListPromptsByPage(Any[context.Context](), Any[mcp.ListPromptsRequest]())).
+		ThenReturn(&mcp.ListPromptsResult{Prompts: []mcp.Prompt{mcp.NewPrompt("forecast")}}, nil)
+	When(toolsOnlyClient.GetServerCapabilities()).ThenReturn(mcp.ServerCapabilities{})
+
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{})
+	msc.sessions["weather"] = weatherClient
+	msc.sessions["math"] = mathClient
+	msc.sessions["tools"] = toolsOnlyClient
+
+	prompts, err := msc.ListPrompts(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, prompts, 3)
+	assert.Equal(t, "math", prompts[0].ServerName)
+	assert.Equal(t, "solve", prompts[0].Name)
+	assert.Equal(t, "Solves an equation", prompts[0].Description)
+	assert.Equal(t, []mcp.PromptArgument{{Name: "equation", Required: true}}, prompts[0].Arguments)
+	assert.Equal(t, ServerPrompt{Prompt: mcp.NewPrompt("explain"), ServerName: "math"}, prompts[1])
+	assert.Equal(t, ServerPrompt{Prompt: mcp.NewPrompt("forecast"), ServerName: "weather"}, prompts[2])
+	Verify(toolsOnlyClient, Never()).ListPromptsByPage(Any[context.Context](), Any[mcp.ListPromptsRequest]())
+}
+
+func TestMultiServerMCPClient_ListPrompts_PartialFailure(t *testing.T) {
+	SetUp(t)
+
+	okClient := Mock[MockMCPClientInternal]()
+	failingClient := Mock[MockMCPClientInternal]()
+	When(okClient.ListPromptsByPage(Any[context.Context](), Any[mcp.ListPromptsRequest]())).
+		ThenReturn(&mcp.ListPromptsResult{Prompts: []mcp.Prompt{mcp.NewPrompt("forecast")}}, nil)
+	When(failingClient.ListPromptsByPage(Any[context.Context](), Any[mcp.ListPromptsRequest]())).
+		ThenReturn(nil, errors.New("method not found"))
+
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{})
+	msc.sessions["weather"] = okClient
+	msc.sessions["broken"] = failingClient
+
+	prompts, err := msc.ListPrompts(context.Background())
+
+	assert.ErrorContains(t, err, "failed to list prompts of broken")
+	assert.Equal(t, []ServerPrompt{{Prompt: mcp.NewPrompt("forecast"), ServerName: "weather"}}, prompts)
+}
+
+func TestMultiServerMCPClient_ListPrompts_Cache(t *testing.T) {
+	SetUp(t)
+
+	mockClient := Mock[MockMCPClientInternal]()
+	When(mockClient.ListPromptsByPage(Any[context.Context](), Any[mcp.ListPromptsRequest]())).
+		ThenReturn(&mcp.ListPromptsResult{Prompts: []mcp.Prompt{mcp.NewPrompt("forecast")}}, nil)
+
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{}, WithPromptCache())
+	msc.sessions["weather"] = mockClient
+
+	for range 2 {
+		prompts, err := msc.ListPrompts(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, prompts, 1)
+	}
+	Verify(mockClient, Once()).ListPromptsByPage(Any[context.Context](), Any[mcp.ListPromptsRequest]())
+
+	msc.notificationHandler("weather", nil, nil)(promptsListChangedNotification())
+	_, err := msc.ListPrompts(context.Background())
+	require.NoError(t, err)
+	Verify(mockClient, Times(2)).ListPromptsByPage(Any[context.Context](), Any[mcp.ListPromptsRequest]())
+}
+
+func TestMultiServerMCPClient_FindPrompt(t *testing.T) {
+	SetUp(t)
+
+	mathClient := Mock[MockMCPClientInternal]()
+	docsClient := Mock[MockMCPClientInternal]()
+	When(mathClient.ListPromptsByPage(Any[context.Context](), Any[mcp.ListPromptsRequest]())).
+		ThenReturn(&mcp.ListPromptsResult{Prompts: []mcp.Prompt{mcp.NewPrompt("solve"), mcp.NewPrompt("explain")}}, nil)
+	When(docsClient.ListPromptsByPage(Any[context.Context](), Any[mcp.ListPromptsRequest]())).
+		ThenReturn(&mcp.ListPromptsResult{Prompts: []mcp.Prompt{mcp.NewPrompt("explain")}}, nil)
+
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{})
+	msc.sessions["math"] = mathClient
+	msc.sessions["docs"] = docsClient
+
+	prompt, err := msc.FindPrompt(context.Background(), "solve")
+	require.NoError(t, err)
+	assert.Equal(t, "math", prompt.ServerName)
+
+	_, err = msc.FindPrompt(context.Background(), "explain")
+	var ambiguous *AmbiguousPromptError
+	require.ErrorAs(t, err, &ambiguous)
+	assert.Equal(t, []string{"docs", "math"}, ambiguous.ServerNames)
+
+	_, err = msc.FindPrompt(context.Background(), "translate")
+	assert.ErrorIs(t, err, ErrPromptNotFound)
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// rootListChanger is implemented by sessions that can send notifications/roots/list_changed.
+type rootListChanger interface {
+	RootListChanges(ctx context.Context) error
+}
+
+// rootsHandler answers roots/list requests from servers with the roots of the client.
+type rootsHandler struct {
+	c *MultiServerMCPClient
+}
+
+var _ client.RootsHandler = rootsHandler{}
+
+// ListRoots implements client.RootsHandler.
+func (h rootsHandler) ListRoots(ctx context.Context, request mcp.ListRootsRequest) (*mcp.ListRootsResult, error) {
+	return &mcp.ListRootsResult{Roots: h.c.Roots()}, nil
+}
+
+// WithRoots exposes roots (e.g. workspace directories) to servers through roots/list
+// and advertises the roots capability with listChanged. Root URIs must start with file://,
+// otherwise Start fails. Only stdio servers can send requests, as the SSE transport of mcp-go
+// does not support them.
+func WithRoots(roots ...mcp.Root) Option {
+	return func(c *MultiServerMCPClient) {
+		c.roots = slices.Clone(roots)
+		c.clientCapabilities.Roots = &struct {
+			ListChanged bool `json:"listChanged,omitempty"`
+		}{ListChanged: true}
+	}
+}
+
+// validateRoots checks that every root URI starts with file://.
+func validateRoots(roots []mcp.Root) error {
+	for _, root := range roots {
+		if !strings.HasPrefix(root.URI, "file://") {
+			return fmt.Errorf("invalid root URI %q: must start with file://", root.URI)
+		}
+	}
+	return nil
+}
+
+// Roots returns the roots currently exposed to servers.
+func (c *MultiServerMCPClient) Roots() []mcp.Root {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Clone(c.roots)
+}
+
+// SetRoots replaces the roots exposed to servers and sends notifications/roots/list_changed
+// to every connected stdio server. The client must have been created with WithRoots.
+func (c *MultiServerMCPClient) SetRoots(ctx context.Context, roots ...mcp.Root) error {
+	if err := validateRoots(roots); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	if c.clientCapabilities.Roots == nil {
+		c.mu.Unlock()
+		return fmt.Errorf("roots capability is not enabled, create the client with WithRoots")
+	}
+	c.roots = slices.Clone(roots)
+	sessions := make(map[string]client.MCPClient, len(c.sessions))
+	for name, session := range c.sessions {
+		if _, ok := c.connections[name].(SSEConnection); ok {
+			continue // Roots are not advertised to SSE servers, see sessionCapabilities
+		}
+		sessions[name] = session
+	}
+	c.mu.Unlock()
+
+	var errs []error
+	for name, session := range sessions {
+		changer, ok := session.(rootListChanger)
+		if !ok {
+			c.logger.Debug("SetRoots session cannot send roots list changes, skipping", "server_name", name)
+			continue
+		}
+		if err := changer.RootListChanges(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to notify %s of roots change: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	. "github.com/ovechkin-dm/mockio/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MockRootsMCPClient interface {
+	MockMCPClientInternal
+	RootListChanges(ctx context.Context) error
+}
+
+func TestNewMultiServerMCPClient_WithRoots(t *testing.T) {
+	roots := []mcp.Root{{URI: "file:///workspace", Name: "workspace"}}
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{}, WithRoots(roots...))
+
+	require.NotNil(t, msc.clientCapabilities.Roots)
+	assert.True(t, msc.clientCapabilities.Roots.ListChanged)
+	assert.Len(t, msc.mcpClientOptions("server1"), 1)
+
+	result, err := rootsHandler{c: msc}.ListRoots(context.Background(), mcp.ListRootsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, roots, result.Roots)
+}
+
+func TestMultiServerMCPClient_SetRoots(t *testing.T) {
+	SetUp(t)
+
+	mockClient1 := Mock[MockRootsMCPClient]()
+	mockClient2 := Mock[MockRootsMCPClient]()
+	plainClient := Mock[MockMCPClientInternal]()
+	When(mockClient1.RootListChanges(Any[context.Context]())).ThenReturn(nil)
+	When(mockClient2.RootListChanges(Any[context.Context]())).ThenReturn(errors.New("broken pipe"))
+
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{}, WithRoots())
+	msc.sessions["server1"] = mockClient1
+	msc.sessions["server2"] = mockClient2
+	msc.sessions["server3"] = plainClient
+
+	newRoots := []mcp.Root{{URI: "file:///repo", Name: "repo"}}
+	err := msc.SetRoots(context.Background(), newRoots...)
+
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "server2")
+	assert.ErrorContains(t, err, "broken pipe")
+	assert.Equal(t, newRoots, msc.Roots(), "Roots should be updated even if a notification fails")
+	Verify(mockClient1, Once()).RootListChanges(Any[context.Context]())
+	Verify(mockClient2, Once()).RootListChanges(Any[context.Context]())
+}
+
+func TestMultiServerMCPClient_SetRoots_Errors(t *testing.T) {
+	withoutRoots := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{})
+	err := withoutRoots.SetRoots(context.Background(), mcp.Root{URI: "file:///repo"})
+	assert.ErrorContains(t, err, "WithRoots")
+
+	withRoots := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{}, WithRoots())
+	err = withRoots.SetRoots(context.Background(), mcp.Root{URI: "https://example.com"})
+	assert.ErrorContains(t, err, "must start with file://")
+	assert.Empty(t, withRoots.Roots())
+}
+
+func TestMultiServerMCPClient_Start_InvalidRoots(t *testing.T) {
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{},
+		WithRoots(mcp.Root{URI: "file:///workspace"}, mcp.Root{URI: "/home/me/project"}))
+
+	err := msc.Start(context.Background())
+
+	assert.EqualError(t, err, `invalid root URI "/home/me/project": must start with file://`)
+	assert.Nil(t, msc.eg, "The client should not be started")
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// sandboxedCommand returns a command that runs command under the limits and identity of
+// sandbox. The resource limits are set by a shell, which replaces itself with the command, so
+// that they apply to the subprocess only and not to this process.
+func sandboxedCommand(ctx context.Context, sandbox *StdioSandbox, command string, args []string) (*exec.Cmd, error) {
+	var limits []string
+	if sandbox.CPUSeconds > 0 {
+		limits = append(limits, fmt.Sprintf("ulimit -t %d", sandbox.CPUSeconds))
+	}
+	if sandbox.MemoryBytes > 0 {
+		// ulimit -v counts kibibytes
+		limits = append(limits, fmt.Sprintf("ulimit -v %d", max(sandbox.MemoryBytes/1024, 1)))
+	}
+	if sandbox.OpenFiles > 0 {
+		limits = append(limits, fmt.Sprintf("ulimit -n %d", sandbox.OpenFiles))
+	}
+
+	cmd := exec.CommandContext(ctx, command, args...)
+	if len(limits) > 0 {
+		// The command and its arguments are passed as $0 and $@, so they are never parsed by the shell
+		script := strings.Join(append(limits, `exec "$0" "$@"`), " && ")
+		cmd = exec.CommandContext(ctx, "/bin/sh", append([]string{"-c", script, command}, args...)...)
+	}
+
+	if sandbox.UID != nil || sandbox.GID != nil {
+		credential := &syscall.Credential{
+			Uid: uint32(os.Getuid()),
+			Gid: uint32(os.Getgid()),
+			// Supplementary groups can only be dropped with privileges
+			NoSetGroups: os.Geteuid() != 0,
+		}
+		if sandbox.UID != nil {
+			credential.Uid = *sandbox.UID
+		}
+		if sandbox.GID != nil {
+			credential.Gid = *sandbox.GID
+		}
+		cmd.SysProcAttr = &syscall.SysProcAttr{Credential: credential}
+	}
+	return cmd, nil
+}
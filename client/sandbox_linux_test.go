@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStdioCommand_SandboxLimits(t *testing.T) {
+	config := StdioConnection{Sandbox: &StdioSandbox{CPUSeconds: 30, MemoryBytes: 1 << 30, OpenFiles: 64}}
+
+	cmd, err := stdioCommand(config)(context.Background(), "sh", []string{"PATH=" + os.Getenv("PATH")},
+		[]string{"-c", "ulimit -t; ulimit -v; ulimit -n"})
+	require.NoError(t, err)
+	output, err := cmd.Output()
+
+	require.NoError(t, err)
+	assert.Equal(t, "30\n1048576\n64\n", string(output))
+}
+
+func TestStdioCommand_SandboxIdentity(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("switching users requires root")
+	}
+	nobody := uint32(65534)
+	config := StdioConnection{Sandbox: &StdioSandbox{UID: &nobody, GID: &nobody}}
+
+	cmd, err := stdioCommand(config)(context.Background(), "sh", []string{"PATH=" + os.Getenv("PATH")},
+		[]string{"-c", "id -u; id -g; id -G"})
+	require.NoError(t, err)
+	output, err := cmd.Output()
+
+	require.NoError(t, err)
+	assert.Equal(t, "65534\n65534\n65534\n", string(output), "Supplementary groups should be dropped")
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+
+package client
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+)
+
+// sandboxedCommand fails, as the sandbox of stdio connections is only supported on Linux.
+func sandboxedCommand(context.Context, *StdioSandbox, string, []string) (*exec.Cmd, error) {
+	return nil, errors.New("stdio sandbox is only supported on Linux")
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"maps"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+)
+
+// StdioSandbox restricts the subprocess of a stdio connection. It is only supported on Linux.
+type StdioSandbox struct {
+	CPUSeconds  uint64  `json:"cpu_seconds,omitempty"`  // Limit of CPU time (RLIMIT_CPU)
+	MemoryBytes uint64  `json:"memory_bytes,omitempty"` // Limit of virtual memory (RLIMIT_AS)
+	OpenFiles   uint64  `json:"open_files,omitempty"`   // Limit of open file descriptors (RLIMIT_NOFILE)
+	UID         *uint32 `json:"uid,omitempty"`          // User to run as, which requires the privilege to switch to it
+	GID         *uint32 `json:"gid,omitempty"`          // Group to run as, which requires the privilege to switch to it
+}
+
+// DefaultInheritedEnv returns the names of the environment variables that stdio servers inherit
+// from this process by default, which are needed to find and run commands but hold no secrets.
+func DefaultInheritedEnv() []string {
+	if runtime.GOOS == "windows" {
+		return []string{
+			"APPDATA", "HOMEDRIVE", "HOMEPATH", "LOCALAPPDATA", "PATH", "PATHEXT", "PROCESSOR_ARCHITECTURE",
+			"SYSTEMDRIVE", "SYSTEMROOT", "TEMP", "USERNAME", "USERPROFILE",
+		}
+	}
+	return []string{"HOME", "LANG", "LC_ALL", "LOGNAME", "PATH", "SHELL", "TERM", "TMPDIR", "USER"}
+}
+
+// stdioEnv returns the environment of the subprocess of a stdio connection: the variables of
+// this process that are inherited, overridden by Env and then by the resolved credentials.
+func stdioEnv(config StdioConnection, secrets map[string]string) []string {
+	env := make(map[string]string)
+	if config.InheritEnv {
+		for _, entry := range os.Environ() {
+			if name, value, ok := strings.Cut(entry, "="); ok && name != "" {
+				env[name] = value
+			}
+		}
+	} else {
+		for _, name := range append(DefaultInheritedEnv(), config.PassthroughEnv...) {
+			if value, ok := os.LookupEnv(name); ok {
+				env[name] = value
+			}
+		}
+	}
+	maps.Copy(env, config.Env)
+	maps.Copy(env, secrets)
+
+	envList := make([]string, 0, len(env))
+	for _, name := range slices.Sorted(maps.Keys(env)) {
+		envList = append(envList, name+"="+env[name])
+	}
+	return envList
+}
+
+// stdioCommand returns the factory of the subprocess of a stdio connection. Unlike the default
+// of mcp-go, it does not add the environment of this process to env.
+func stdioCommand(config StdioConnection) transport.CommandFunc {
+	return func(ctx context.Context, command string, env []string, args []string) (*exec.Cmd, error) {
+		var cmd *exec.Cmd
+		if config.Sandbox == nil {
+			cmd = exec.CommandContext(ctx, command, args...)
+		} else {
+			var err error
+			if cmd, err = sandboxedCommand(ctx, config.Sandbox, command, args); err != nil {
+				return nil, err
+			}
+		}
+		cmd.Env = env
+		cmd.Dir = config.Cwd
+		return cmd, nil
+	}
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStdioEnv(t *testing.T) {
+	t.Setenv("PATH", "/usr/local/bin:/usr/bin:/bin")
+	t.Setenv("MCP_TEST_SERVICE_SECRET", "do-not-leak")
+	t.Setenv("MCP_TEST_PASSTHROUGH", "passed")
+
+	env := stdioEnv(StdioConnection{
+		PassthroughEnv: []string{"MCP_TEST_PASSTHROUGH", "MCP_TEST_UNSET"},
+		Env:            map[string]string{"LOG_LEVEL": "debug", "API_KEY": "from-env"},
+	}, map[string]string{"API_KEY": "from-credential"})
+
+	assert.Contains(t, env, "PATH=/usr/local/bin:/usr/bin:/bin")
+	assert.Contains(t, env, "MCP_TEST_PASSTHROUGH=passed")
+	assert.Contains(t, env, "LOG_LEVEL=debug")
+	assert.Contains(t, env, "API_KEY=from-credential", "Credentials should override Env")
+	assert.NotContains(t, env, "API_KEY=from-env")
+	assert.NotContains(t, env, "MCP_TEST_SERVICE_SECRET=do-not-leak")
+	paths := 0
+	for _, entry := range env {
+		if strings.HasPrefix(entry, "PATH=") {
+			paths++
+		}
+	}
+	assert.Equal(t, 1, paths, "PATH should be set once")
+
+	overridden := stdioEnv(StdioConnection{Env: map[string]string{"PATH": "/opt/server/bin"}}, nil)
+	assert.Contains(t, overridden, "PATH=/opt/server/bin")
+	assert.NotContains(t, overridden, "PATH=/usr/local/bin:/usr/bin:/bin")
+
+	inherited := stdioEnv(StdioConnection{InheritEnv: true}, nil)
+	assert.Contains(t, inherited, "MCP_TEST_SERVICE_SECRET=do-not-leak")
+}
+
+func TestStdioCommand(t *testing.T) {
+	t.Setenv("MCP_TEST_SERVICE_SECRET", "do-not-leak")
+	dir := t.TempDir()
+	config := StdioConnection{Cwd: dir}
+
+	cmd, err := stdioCommand(config)(context.Background(), "sh", []string{"SERVER_MODE=test"},
+		[]string{"-c", `echo "$SERVER_MODE:$MCP_TEST_SERVICE_SECRET"; pwd`})
+	require.NoError(t, err)
+	output, err := cmd.Output()
+
+	require.NoError(t, err)
+	resolved, err := filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "test:\n"+resolved+"\n", string(output))
+}
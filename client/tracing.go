@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/internal/telemetry"
+)
+
+// WithTracerProvider sets the OpenTelemetry tracer provider of the spans for connecting to
+// servers, initializing sessions, listing tools, calling tools and getting prompts.
+// Defaults to the global tracer provider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *MultiServerMCPClient) {
+		c.telemetry.TracerProvider = tp
+	}
+}
+
+// WithPropagator sets the propagator injecting the trace context into the HTTP headers of
+// requests to SSE servers and into the _meta of tool calls. Defaults to the global propagator.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(c *MultiServerMCPClient) {
+		c.telemetry.Propagator = propagator
+	}
+}
+
+// transportName returns the transport of a connection config for span attributes.
+func transportName(config ConnectionConfig) string {
+	switch config.(type) {
+	case StdioConnection:
+		return "stdio"
+	case SSEConnection:
+		return "sse"
+	default:
+		return "unknown"
+	}
+}
+
+// startServerSpan starts a span named name for an operation on serverName.
+func (c *MultiServerMCPClient) startServerSpan(ctx context.Context, name, serverName string) (context.Context, trace.Span) {
+	return c.telemetry.Tracer().Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(telemetry.ServerNameKey.String(serverName)),
+	)
+}
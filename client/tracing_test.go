@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestMultiServerMCPClient_Tracing(t *testing.T) {
+	mcpServer := server.NewMCPServer("traced", "1.0.0")
+	mcpServer.AddTool(mcp.NewTool("echo", mcp.WithString("text", mcp.Required())),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(request.GetString("text", "")), nil
+		})
+	sseServer := server.NewSSEServer(mcpServer)
+
+	var mu sync.Mutex
+	var traceparents []string
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			mu.Lock()
+			traceparents = append(traceparents, r.Header.Get("traceparent"))
+			mu.Unlock()
+		}
+		sseServer.ServeHTTP(w, r)
+	}))
+	defer httpServer.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{}, mcp.Implementation{}, mcp.ClientCapabilities{},
+		WithTracerProvider(tp),
+		WithPropagator(propagation.TraceContext{}),
+	)
+
+	session, err := msc.connectToServerViaSSE(context.Background(), "echo-server", SSEConnection{URL: httpServer.URL + "/sse"})
+	require.NoError(t, err)
+	msc.sessions["echo-server"] = session
+	defer msc.Close()
+
+	ctx, start := tp.Tracer("test").Start(context.Background(), "start")
+	require.NoError(t, msc.initializeSessionAndLoadTools(ctx, "echo-server", session))
+	start.End()
+
+	loadedTools := msc.GetTools()
+	require.Len(t, loadedTools, 1)
+	output, err := loadedTools[0].Call(context.Background(), `{"text": "hello"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", output)
+
+	spans := map[string]tracetest.SpanStub{}
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+	for _, name := range []string{"initialize", "tools/list"} {
+		require.Contains(t, spans, name)
+		assert.Equal(t, start.SpanContext().SpanID(), spans[name].Parent.SpanID(), name)
+		assert.Contains(t, spans[name].Attributes, attribute.String("mcp.server.name", "echo-server"), name)
+	}
+	assert.Contains(t, spans["initialize"].Attributes, attribute.String("mcp.server.info.name", "traced"))
+	require.Contains(t, spans, "tools/call echo")
+	assert.Contains(t, spans["tools/call echo"].Attributes, attribute.String("mcp.server.name", "echo-server"))
+
+	// Every request carries the trace context of its span in the traceparent header
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, traceparents)
+	for _, traceparent := range traceparents {
+		assert.NotEmpty(t, traceparent)
+	}
+	assert.Contains(t, traceparents[len(traceparents)-1], spans["tools/call echo"].SpanContext.SpanID().String())
+}
+
+func TestMultiServerMCPClient_Start_Tracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	msc := NewMultiServerMCPClient(map[string]ConnectionConfig{"broken": struct{}{}},
+		mcp.Implementation{}, mcp.ClientCapabilities{}, WithTracerProvider(tp))
+
+	err := msc.Start(context.Background())
+	require.Error(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	connect, start := spans[0], spans[1]
+	assert.Equal(t, "MultiServerMCPClient.Start", start.Name)
+	assert.Equal(t, codes.Error, start.Status.Code)
+	assert.Equal(t, "mcp.connect", connect.Name)
+	assert.Equal(t, start.SpanContext.SpanID(), connect.Parent.SpanID())
+	assert.Equal(t, codes.Error, connect.Status.Code)
+	assert.Contains(t, connect.Attributes, attribute.String("mcp.server.name", "broken"))
+	assert.Contains(t, connect.Attributes, attribute.String("mcp.transport", "unknown"))
+}
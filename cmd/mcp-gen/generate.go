@@ -0,0 +1,315 @@
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/internal/jsonschema"
+)
+
+// generator accumulates the declarations of the generated file.
+type generator struct {
+	decls []string
+	names map[string]bool
+}
+
+// Generate returns the formatted source of a Go package with an argument struct and a typed
+// method on Client for every tool. Tools with an output schema also get a result struct.
+func Generate(packageName string, mcpTools []mcp.Tool) ([]byte, error) {
+	g := &generator{names: map[string]bool{"Client": true}}
+
+	mcpTools = slices.Clone(mcpTools)
+	sort.Slice(mcpTools, func(i, j int) bool { return mcpTools[i].Name < mcpTools[j].Name })
+	for _, mcpTool := range mcpTools {
+		if err := g.generateTool(mcpTool); err != nil {
+			return nil, fmt.Errorf("failed to generate tool %s: %w", mcpTool.Name, err)
+		}
+	}
+
+	var src strings.Builder
+	src.WriteString("// Code generated by mcp-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", packageName)
+	src.WriteString(header)
+	for _, decl := range g.decls {
+		src.WriteString("\n")
+		src.WriteString(decl)
+	}
+
+	formatted, err := format.Source([]byte(src.String()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+// generateTool emits the argument struct, result struct and method of a tool.
+func (g *generator) generateTool(mcpTool mcp.Tool) error {
+	inputSchema, err := toolSchema(mcpTool.RawInputSchema, mcpTool.InputSchema, mcpTool.InputSchema.Type)
+	if err != nil {
+		return fmt.Errorf("invalid input schema: %w", err)
+	}
+	outputSchema, err := toolSchema(mcpTool.RawOutputSchema, mcpTool.OutputSchema, mcpTool.OutputSchema.Type)
+	if err != nil {
+		return fmt.Errorf("invalid output schema: %w", err)
+	}
+
+	methodName := g.uniqueName(exportedName(mcpTool.Name))
+	argsName := g.uniqueName(methodName + "Args")
+	g.generateStruct(argsName, fmt.Sprintf("%s are the arguments of the %s tool.", argsName, mcpTool.Name), inputSchema)
+
+	var method strings.Builder
+	method.WriteString(comment(fmt.Sprintf("%s calls the %s tool.", methodName, mcpTool.Name)))
+	if mcpTool.Description != "" {
+		method.WriteString("//\n")
+		method.WriteString(comment(mcpTool.Description))
+	}
+
+	if outputSchema == nil {
+		fmt.Fprintf(&method, "func (c *Client) %s(ctx context.Context, args %s) (string, error) {\n", methodName, argsName)
+		fmt.Fprintf(&method, "\tresult, err := c.call(ctx, %q, args)\n", mcpTool.Name)
+		method.WriteString("\tif err != nil {\n\t\treturn \"\", err\n\t}\n")
+		method.WriteString("\treturn resultText(result), nil\n}\n")
+		g.decls = append(g.decls, method.String())
+		return nil
+	}
+
+	resultName := g.uniqueName(methodName + "Result")
+	fmt.Fprintf(&method, "func (c *Client) %s(ctx context.Context, args %s) (%s, error) {\n", methodName, argsName, resultName)
+	fmt.Fprintf(&method, "\tvar output %s\n", resultName)
+	fmt.Fprintf(&method, "\tresult, err := c.call(ctx, %q, args)\n", mcpTool.Name)
+	method.WriteString("\tif err != nil {\n\t\treturn output, err\n\t}\n")
+	fmt.Fprintf(&method, "\terr = decodeStructured(%q, result, &output)\n", mcpTool.Name)
+	method.WriteString("\treturn output, err\n}\n")
+	g.decls = append(g.decls, method.String())
+	g.generateStruct(resultName, fmt.Sprintf("%s is the structured result of the %s tool.", resultName, mcpTool.Name), outputSchema)
+	return nil
+}
+
+// toolSchema returns the schema of a tool in its generic map form, or nil if it has none.
+func toolSchema(raw []byte, schema any, schemaType string) (map[string]any, error) {
+	if raw != nil {
+		return jsonschema.Normalize(raw)
+	}
+	if schemaType == "" {
+		return nil, nil
+	}
+	return jsonschema.Normalize(schema)
+}
+
+// generateStruct emits a struct with a field for every property of an object schema.
+// Nested object schemas become structs named after their parent and field.
+func (g *generator) generateStruct(name, doc string, schema map[string]any) {
+	// Reserve the position, so nested structs follow their parent
+	index := len(g.decls)
+	g.decls = append(g.decls, "")
+
+	properties, _ := schema["properties"].(map[string]any)
+	required := jsonschema.Required(schema)
+
+	propertyNames := make([]string, 0, len(properties))
+	for propertyName := range properties {
+		propertyNames = append(propertyNames, propertyName)
+	}
+	sort.Strings(propertyNames)
+
+	var decl strings.Builder
+	decl.WriteString(comment(doc))
+	fmt.Fprintf(&decl, "type %s struct {\n", name)
+	fieldNames := map[string]bool{}
+	for _, propertyName := range propertyNames {
+		propertySchema, _ := properties[propertyName].(map[string]any)
+		fieldName := exportedName(propertyName)
+		for i := 2; fieldNames[fieldName]; i++ {
+			fieldName = fmt.Sprintf("%s%d", exportedName(propertyName), i)
+		}
+		fieldNames[fieldName] = true
+
+		var doc []string
+		if description, ok := propertySchema["description"].(string); ok && description != "" {
+			doc = append(doc, description)
+		}
+		if enum, ok := propertySchema["enum"].([]any); ok {
+			values := make([]string, 0, len(enum))
+			for _, value := range enum {
+				values = append(values, fmt.Sprintf("%v", value))
+			}
+			doc = append(doc, "One of: "+strings.Join(values, ", ")+".")
+		}
+		for _, line := range doc {
+			decl.WriteString(indent(comment(line)))
+		}
+
+		fieldType := g.goType(name+fieldName, fmt.Sprintf("the %s field of %s", propertyName, name), propertySchema, required[propertyName])
+		tag := propertyName
+		if !required[propertyName] {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&decl, "\t%s %s `json:%q`\n", fieldName, fieldType, tag)
+	}
+	decl.WriteString("}\n")
+	g.decls[index] = decl.String()
+}
+
+// goType returns the Go type for a property schema. Optional scalars and structs are pointers,
+// so that unset values are omitted.
+// Nested structs are named typeName and documented as being what.
+func (g *generator) goType(typeName, what string, schema map[string]any, required bool) string {
+	var goType string
+	optionalPointer := !required
+	switch jsonschema.Type(schema) {
+	case "string":
+		goType = "string"
+	case "integer":
+		goType = "int64"
+	case "number":
+		goType = "float64"
+	case "boolean":
+		goType = "bool"
+	case "array":
+		items, _ := schema["items"].(map[string]any)
+		goType = "[]" + g.goType(typeName+"Item", "an item of "+what, items, true)
+		optionalPointer = false
+	case "object":
+		if properties, ok := schema["properties"].(map[string]any); ok && len(properties) > 0 {
+			goType = g.uniqueName(typeName)
+			g.generateStruct(goType, fmt.Sprintf("%s is %s.", goType, what), schema)
+		} else {
+			goType = "map[string]any"
+			optionalPointer = false
+		}
+	default:
+		goType = "any"
+		optionalPointer = false
+	}
+
+	if optionalPointer {
+		return "*" + goType
+	}
+	return goType
+}
+
+// uniqueName returns name, or name with a numeric suffix if it is already declared.
+func (g *generator) uniqueName(name string) string {
+	unique := name
+	for i := 2; g.names[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	g.names[unique] = true
+	return unique
+}
+
+// exportedName converts a tool or property name such as "get_weather" into an exported
+// Go identifier such as "GetWeather".
+func exportedName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+
+	identifier := b.String()
+	if identifier == "" || !unicode.IsLetter([]rune(identifier)[0]) {
+		identifier = "X" + identifier
+	}
+	return identifier
+}
+
+func comment(text string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		b.WriteString(strings.TrimRight("// "+line, " "))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func indent(text string) string {
+	return "\t" + strings.ReplaceAll(strings.TrimSuffix(text, "\n"), "\n", "\n\t") + "\n"
+}
+
+// header holds the imports, the Client type and the helpers shared by generated methods.
+const header = `import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Client calls the tools of an MCP server with typed arguments.
+type Client struct {
+	mcpClient client.MCPClient
+}
+
+// NewClient creates a Client calling tools through an initialized MCP session.
+func NewClient(mcpClient client.MCPClient) *Client {
+	return &Client{mcpClient: mcpClient}
+}
+
+// call sends tools/call with args encoded as the tool arguments. Tool errors are returned as errors.
+func (c *Client) call(ctx context.Context, name string, args any) (*mcp.CallToolResult, error) {
+	raw, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode arguments of tool %s: %w", name, err)
+	}
+	var arguments map[string]any
+	if err := json.Unmarshal(raw, &arguments); err != nil {
+		return nil, fmt.Errorf("failed to encode arguments of tool %s: %w", name, err)
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = arguments
+	result, err := c.mcpClient.CallTool(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call MCP tool %s: %w", name, err)
+	}
+	if result.IsError {
+		return nil, fmt.Errorf("tool %s execution failed: %s", name, resultText(result))
+	}
+	return result, nil
+}
+
+// resultText joins the text content of result.
+func resultText(result *mcp.CallToolResult) string {
+	var texts []string
+	for _, content := range result.Content {
+		if textContent, ok := content.(mcp.TextContent); ok {
+			texts = append(texts, textContent.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// decodeStructured decodes the structured content of result, or its text content if the
+// tool returned no structured content, into output.
+func decodeStructured(name string, result *mcp.CallToolResult, output any) error {
+	raw := []byte(resultText(result))
+	if result.StructuredContent != nil {
+		var err error
+		if raw, err = json.Marshal(result.StructuredContent); err != nil {
+			return fmt.Errorf("failed to decode result of tool %s: %w", name, err)
+		}
+	}
+	if err := json.Unmarshal(raw, output); err != nil {
+		return fmt.Errorf("failed to decode result of tool %s: %w", name, err)
+	}
+	return nil
+}
+`
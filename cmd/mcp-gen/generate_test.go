@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+type Forecast struct {
+	City  string    `json:"city"`
+	Highs []float64 `json:"highs"`
+}
+
+// newTestServer returns a server with tools covering the supported schema features.
+func newTestServer() *server.MCPServer {
+	s := server.NewMCPServer("test", "1.0.0")
+	s.AddTool(mcp.NewTool("add",
+		mcp.WithDescription("Add two numbers"),
+		mcp.WithNumber("a", mcp.Description("First number"), mcp.Required()),
+		mcp.WithNumber("b", mcp.Description("Second number"), mcp.Required()),
+	), nil)
+	s.AddTool(mcp.NewTool("get_forecast",
+		mcp.WithDescription("Get the weather forecast.\nReturns daily highs."),
+		mcp.WithString("city", mcp.Required()),
+		mcp.WithNumber("days", mcp.Description("Number of days")),
+		mcp.WithString("unit", mcp.Enum("celsius", "fahrenheit")),
+		mcp.WithBoolean("include-night"),
+		mcp.WithArray("tags", mcp.WithStringItems()),
+		mcp.WithObject("location", mcp.Properties(map[string]any{
+			"lat": map[string]any{"type": "number"},
+			"lon": map[string]any{"type": "number"},
+		}), mcp.Required()),
+		mcp.WithObject("extra"),
+		mcp.WithArray("stops", mcp.Items(map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"name": map[string]any{"type": "string"}},
+			"required":   []string{"name"},
+		})),
+		mcp.WithOutputSchema[Forecast](),
+	), nil)
+	s.AddTool(mcp.NewToolWithRawSchema("list-items", "", []byte(`{
+		"type": "object",
+		"properties": {
+			"limit": {"type": "integer"},
+			"cursor": {"type": ["string", "null"]},
+			"filter": {}
+		},
+		"required": ["limit"]
+	}`)), nil)
+	return s
+}
+
+func listTestTools(t *testing.T) []mcp.Tool {
+	t.Helper()
+	mcpClient, err := client.NewInProcessClient(newTestServer())
+	require.NoError(t, err)
+	require.NoError(t, mcpClient.Start(context.Background()))
+	t.Cleanup(func() { _ = mcpClient.Close() })
+
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	_, err = mcpClient.Initialize(context.Background(), initRequest)
+	require.NoError(t, err)
+
+	listResult, err := mcpClient.ListTools(context.Background(), mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	return listResult.Tools
+}
+
+func TestGenerate_Golden(t *testing.T) {
+	src, err := Generate("testtools", listTestTools(t))
+	require.NoError(t, err)
+
+	golden := filepath.Join("testdata", "tools.go.golden")
+	if *update {
+		require.NoError(t, os.WriteFile(golden, src, 0o644))
+	}
+	expected, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(src), "Run go test ./cmd/mcp-gen -update to update the golden file")
+}
+
+func TestGenerate_TypeChecks(t *testing.T) {
+	src, err := Generate("testtools", listTestTools(t))
+	require.NoError(t, err)
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "tools.go", src, 0)
+	require.NoError(t, err)
+	config := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = config.Check("testtools", fset, []*ast.File{file}, nil)
+	assert.NoError(t, err)
+}
+
+func TestExportedName(t *testing.T) {
+	assert.Equal(t, "GetWeather", exportedName("get_weather"))
+	assert.Equal(t, "IncludeNight", exportedName("include-night"))
+	assert.Equal(t, "FetchURL", exportedName("fetchURL"))
+	assert.Equal(t, "X2fa", exportedName("2fa"))
+	assert.Equal(t, "X", exportedName("__"))
+}
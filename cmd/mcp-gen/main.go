@@ -0,0 +1,81 @@
+// Command mcp-gen generates typed Go wrappers for the tools of an MCP server.
+//
+// It connects to the server, lists its tools and writes a Go file with an argument struct
+// for every input schema and a typed method calling the tool through a client.MCPClient:
+//
+//	mcp-gen -package mathtools -out mathtools/tools.go -- /path/to/math-server
+//	mcp-gen -package weather -out weather/tools.go -url http://localhost:8081/sse
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	mcpclient "github.com/akihiro-fukuchi/langchaingo-mcp-adapters/client"
+)
+
+const serverName = "server"
+
+func main() {
+	packageName := flag.String("package", "tools", "Name of the generated Go package")
+	out := flag.String("out", "", "Output file (default: standard output)")
+	url := flag.String("url", "", "URL of an SSE server; if empty, the arguments after -- start a stdio server")
+	timeout := flag.Duration("timeout", 30*time.Second, "Timeout for connecting and listing tools")
+	flag.Parse()
+
+	if err := run(*packageName, *out, *url, flag.Args(), *timeout); err != nil {
+		fmt.Fprintln(os.Stderr, "mcp-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(packageName, out, url string, command []string, timeout time.Duration) error {
+	var config mcpclient.ConnectionConfig
+	switch {
+	case url != "":
+		config = mcpclient.SSEConnection{Transport: "sse", URL: url}
+	case len(command) > 0:
+		config = mcpclient.StdioConnection{Transport: "stdio", Command: command[0], Args: command[1:]}
+	default:
+		return fmt.Errorf("either -url or a server command after -- is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client := mcpclient.NewMultiServerMCPClient(
+		map[string]mcpclient.ConnectionConfig{serverName: config},
+		mcp.Implementation{Name: "mcp-gen"},
+		mcp.ClientCapabilities{},
+	)
+	if err := client.Start(ctx); err != nil {
+		return err
+	}
+	defer client.Close()
+
+	session, err := client.Session(serverName)
+	if err != nil {
+		return err
+	}
+	listResult, err := session.ListTools(ctx, mcp.ListToolsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list tools: %w", err)
+	}
+	slog.Debug("mcp-gen listed tools", "count", len(listResult.Tools))
+
+	src, err := Generate(packageName, listResult.Tools)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
@@ -11,8 +11,8 @@ import (
 )
 
 func handleAddTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	a, ok1 := request.Params.Arguments["a"].(float64)
-	b, ok2 := request.Params.Arguments["b"].(float64)
+	a, ok1 := request.GetArguments()["a"].(float64)
+	b, ok2 := request.GetArguments()["b"].(float64)
 	if !ok1 || !ok2 {
 		return mcp.NewToolResultError("invalid number arguments"), nil
 	}
@@ -21,8 +21,8 @@ func handleAddTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallT
 }
 
 func handleMultiplyTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	a, ok1 := request.Params.Arguments["a"].(float64)
-	b, ok2 := request.Params.Arguments["b"].(float64)
+	a, ok1 := request.GetArguments()["a"].(float64)
+	b, ok2 := request.GetArguments()["b"].(float64)
 	if !ok1 || !ok2 {
 		return mcp.NewToolResultError("invalid number arguments"), nil
 	}
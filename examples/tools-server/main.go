@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"os"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tmc/langchaingo/tools"
+
+	lcgomcpserver "github.com/akihiro-fukuchi/langchaingo-mcp-adapters/server"
+)
+
+// Serves LangchainGo tools as an MCP server over stdio, SSE or Streamable HTTP.
+func main() {
+	transport := flag.String("transport", "stdio", "Transport to serve: stdio, sse or http")
+	addr := flag.String("addr", ":8080", "Listen address for sse and http transports")
+	flag.Parse()
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+
+	mcpServer := server.NewMCPServer("langchaingo-tools", "1.0.0", server.WithToolCapabilities(false))
+	if err := lcgomcpserver.AddTools(mcpServer, tools.Calculator{}); err != nil {
+		slog.Error("Failed to register tools", "error", err)
+		os.Exit(1)
+	}
+
+	var err error
+	switch *transport {
+	case "stdio":
+		slog.Info("Starting LangchainGo tools server via stdio...")
+		err = server.ServeStdio(mcpServer)
+	case "sse":
+		slog.Info("Starting LangchainGo tools server via SSE...", "addr", *addr)
+		err = server.NewSSEServer(mcpServer).Start(*addr)
+	case "http":
+		slog.Info("Starting LangchainGo tools server via Streamable HTTP...", "addr", *addr)
+		err = server.NewStreamableHTTPServer(mcpServer).Start(*addr)
+	default:
+		slog.Error("Unknown transport", "transport", *transport)
+		os.Exit(1)
+	}
+	if err != nil {
+		slog.Error("Server error", "error", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,326 @@
+// Package jsonschema implements validation of JSON values against the subset of
+// JSON Schema used by MCP for tool input/output schemas and elicitation requests.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationError lists every place where a value does not match its schema.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "schema validation failed: " + strings.Join(e.Problems, "; ")
+}
+
+// Validate checks value against schema. Both may be any JSON-serializable Go value
+// (e.g. map[string]any, json.RawMessage or mcp.ToolInputSchema); they are normalized
+// through encoding/json first. Unknown keywords, including $ref and format, are ignored.
+// A nil schema accepts every value.
+func Validate(schema any, value any) error {
+	if schema == nil {
+		return nil
+	}
+	normalizedSchema, err := Normalize(schema)
+	if err != nil {
+		return fmt.Errorf("invalid schema: %w", err)
+	}
+	normalizedValue, err := normalizeValue(value)
+	if err != nil {
+		return fmt.Errorf("invalid value: %w", err)
+	}
+
+	v := &validator{}
+	v.validate("$", normalizedSchema, normalizedValue)
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+// Normalize converts a schema into its generic map form, as produced by encoding/json.
+func Normalize(schema any) (map[string]any, error) {
+	raw, err := toJSON(schema)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// normalizeValue converts value into the generic types produced by encoding/json.
+// Type returns the type of a schema in its generic map form, ignoring "null" in type lists.
+func Type(schema map[string]any) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []any:
+		for _, item := range t {
+			if s, ok := item.(string); ok && s != "null" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// Required returns the names of the required properties of an object schema in its generic
+// map form.
+func Required(schema map[string]any) map[string]bool {
+	required := map[string]bool{}
+	list, _ := schema["required"].([]any)
+	for _, item := range list {
+		if name, ok := item.(string); ok {
+			required[name] = true
+		}
+	}
+	return required
+}
+
+func normalizeValue(value any) (any, error) {
+	raw, err := toJSON(value)
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func toJSON(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case json.RawMessage:
+		return v, nil
+	case []byte:
+		return v, nil
+	default:
+		return json.Marshal(v)
+	}
+}
+
+type validator struct {
+	problems []string
+}
+
+func (v *validator) fail(path, format string, args ...any) {
+	v.problems = append(v.problems, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (v *validator) validate(path string, schema map[string]any, value any) {
+	if t, ok := schema["type"]; ok && !matchesType(t, value) {
+		v.fail(path, "expected %s, got %s", describeType(t), TypeOf(value))
+		return
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !containsValue(enum, value) {
+		v.fail(path, "value %v is not one of %v", value, enum)
+	}
+	if c, ok := schema["const"]; ok && !reflect.DeepEqual(c, value) {
+		v.fail(path, "value %v does not equal %v", value, c)
+	}
+
+	switch val := value.(type) {
+	case map[string]any:
+		v.validateObject(path, schema, val)
+	case []any:
+		v.validateArray(path, schema, val)
+	case string:
+		v.validateString(path, schema, val)
+	case float64:
+		v.validateNumber(path, schema, val)
+	}
+
+	if all, ok := schema["allOf"].([]any); ok {
+		for _, s := range all {
+			if sub, ok := s.(map[string]any); ok {
+				v.validate(path, sub, value)
+			}
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]any); ok && countMatches(anyOf, value) == 0 {
+		v.fail(path, "value does not match any of the allowed schemas")
+	}
+	if oneOf, ok := schema["oneOf"].([]any); ok && countMatches(oneOf, value) != 1 {
+		v.fail(path, "value must match exactly one of the allowed schemas")
+	}
+}
+
+func (v *validator) validateObject(path string, schema map[string]any, value map[string]any) {
+	if required, ok := schema["required"].([]any); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, present := value[name]; !present {
+				v.fail(path, "missing required property %q", name)
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	for name, propValue := range value {
+		propPath := path + "." + name
+		if propSchema, ok := properties[name].(map[string]any); ok {
+			v.validate(propPath, propSchema, propValue)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				v.fail(path, "unexpected property %q", name)
+			}
+		case map[string]any:
+			v.validate(propPath, additional, propValue)
+		}
+	}
+}
+
+func (v *validator) validateArray(path string, schema map[string]any, value []any) {
+	if minItems, ok := number(schema["minItems"]); ok && float64(len(value)) < minItems {
+		v.fail(path, "expected at least %v items, got %d", minItems, len(value))
+	}
+	if maxItems, ok := number(schema["maxItems"]); ok && float64(len(value)) > maxItems {
+		v.fail(path, "expected at most %v items, got %d", maxItems, len(value))
+	}
+	if items, ok := schema["items"].(map[string]any); ok {
+		for i, item := range value {
+			v.validate(fmt.Sprintf("%s[%d]", path, i), items, item)
+		}
+	}
+}
+
+func (v *validator) validateString(path string, schema map[string]any, value string) {
+	length := float64(utf8.RuneCountInString(value))
+	if minLength, ok := number(schema["minLength"]); ok && length < minLength {
+		v.fail(path, "expected at least %v characters, got %v", minLength, length)
+	}
+	if maxLength, ok := number(schema["maxLength"]); ok && length > maxLength {
+		v.fail(path, "expected at most %v characters, got %v", maxLength, length)
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			v.fail(path, "invalid pattern %q: %v", pattern, err)
+		} else if !re.MatchString(value) {
+			v.fail(path, "value %q does not match pattern %q", value, pattern)
+		}
+	}
+}
+
+func (v *validator) validateNumber(path string, schema map[string]any, value float64) {
+	if minimum, ok := number(schema["minimum"]); ok && value < minimum {
+		v.fail(path, "value %v is less than minimum %v", value, minimum)
+	}
+	if maximum, ok := number(schema["maximum"]); ok && value > maximum {
+		v.fail(path, "value %v is greater than maximum %v", value, maximum)
+	}
+	if exclusiveMinimum, ok := number(schema["exclusiveMinimum"]); ok && value <= exclusiveMinimum {
+		v.fail(path, "value %v must be greater than %v", value, exclusiveMinimum)
+	}
+	if exclusiveMaximum, ok := number(schema["exclusiveMaximum"]); ok && value >= exclusiveMaximum {
+		v.fail(path, "value %v must be less than %v", value, exclusiveMaximum)
+	}
+}
+
+// countMatches returns how many of the schemas accept value.
+func countMatches(schemas []any, value any) int {
+	matches := 0
+	for _, s := range schemas {
+		sub, ok := s.(map[string]any)
+		if !ok {
+			continue
+		}
+		inner := &validator{}
+		inner.validate("$", sub, value)
+		if len(inner.problems) == 0 {
+			matches++
+		}
+	}
+	return matches
+}
+
+// matchesType reports whether value matches a "type" keyword, which is a string or a list of strings.
+func matchesType(t any, value any) bool {
+	switch tt := t.(type) {
+	case string:
+		return matchesSingleType(tt, value)
+	case []any:
+		for _, candidate := range tt {
+			if name, ok := candidate.(string); ok && matchesSingleType(name, value) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+func matchesSingleType(name string, value any) bool {
+	actual := TypeOf(value)
+	switch name {
+	case "number":
+		return actual == "number" || actual == "integer"
+	default:
+		return actual == name
+	}
+}
+
+// TypeOf returns the JSON Schema type name of a normalized JSON value.
+func TypeOf(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func describeType(t any) string {
+	if list, ok := t.([]any); ok {
+		names := make([]string, 0, len(list))
+		for _, n := range list {
+			names = append(names, fmt.Sprint(n))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+func containsValue(values []any, value any) bool {
+	for _, candidate := range values {
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func number(v any) (float64, bool) {
+	f, ok := v.(float64)
+	return f, ok
+}
@@ -0,0 +1,103 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"name":  map[string]any{"type": "string", "minLength": 2, "maxLength": 5},
+			"age":   map[string]any{"type": "integer", "minimum": 0, "maximum": 150},
+			"color": map[string]any{"type": "string", "enum": []string{"red", "green"}},
+			"tags":  map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "maxItems": 2},
+			"code":  map[string]any{"type": "string", "pattern": "^[A-Z]{3}$"},
+			"score": map[string]any{"type": []string{"number", "null"}},
+		},
+		"required":             []string{"name"},
+		"additionalProperties": false,
+	}
+
+	tests := []struct {
+		name     string
+		value    any
+		problems []string
+	}{
+		{name: "Valid", value: map[string]any{"name": "Ann", "age": 30, "color": "red", "tags": []string{"a"}, "code": "ABC", "score": 1.5}},
+		{name: "Null in type list", value: map[string]any{"name": "Ann", "score": nil}},
+		{name: "Missing required", value: map[string]any{}, problems: []string{`$: missing required property "name"`}},
+		{name: "Wrong type", value: map[string]any{"name": 12}, problems: []string{"$.name: expected string, got integer"}},
+		{name: "Too short", value: map[string]any{"name": "A"}, problems: []string{"$.name: expected at least 2 characters, got 1"}},
+		{name: "Not an integer", value: map[string]any{"name": "Ann", "age": 1.5}, problems: []string{"$.age: expected integer, got number"}},
+		{name: "Above maximum", value: map[string]any{"name": "Ann", "age": 200}, problems: []string{"$.age: value 200 is greater than maximum 150"}},
+		{name: "Not in enum", value: map[string]any{"name": "Ann", "color": "blue"}, problems: []string{"$.color: value blue is not one of [red green]"}},
+		{name: "Bad item", value: map[string]any{"name": "Ann", "tags": []any{"a", 1}}, problems: []string{"$.tags[1]: expected string, got integer"}},
+		{name: "Too many items", value: map[string]any{"name": "Ann", "tags": []string{"a", "b", "c"}}, problems: []string{"$.tags: expected at most 2 items, got 3"}},
+		{name: "Pattern mismatch", value: map[string]any{"name": "Ann", "code": "abc"}, problems: []string{`$.code: value "abc" does not match pattern "^[A-Z]{3}$"`}},
+		{name: "Additional property", value: map[string]any{"name": "Ann", "extra": true}, problems: []string{`$: unexpected property "extra"`}},
+		{name: "Not an object", value: "Ann", problems: []string{"$: expected object, got string"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(schema, tt.value)
+			if tt.problems == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.problems, validationErr.Problems)
+		})
+	}
+}
+
+func TestValidate_Combinators(t *testing.T) {
+	anyOf := map[string]any{"anyOf": []any{
+		map[string]any{"type": "string"},
+		map[string]any{"type": "number"},
+	}}
+	assert.NoError(t, Validate(anyOf, "x"))
+	assert.NoError(t, Validate(anyOf, 3))
+	assert.Error(t, Validate(anyOf, true))
+
+	oneOf := map[string]any{"oneOf": []any{
+		map[string]any{"type": "integer"},
+		map[string]any{"type": "number"},
+	}}
+	assert.NoError(t, Validate(oneOf, 1.5))
+	assert.Error(t, Validate(oneOf, 1), "1 matches both integer and number")
+
+	allOf := map[string]any{"allOf": []any{
+		map[string]any{"type": "number", "minimum": 1},
+		map[string]any{"exclusiveMaximum": 10},
+	}}
+	assert.NoError(t, Validate(allOf, 5))
+	assert.Error(t, Validate(allOf, 10))
+}
+
+func TestValidate_SchemaForms(t *testing.T) {
+	type args struct {
+		Query string `json:"query"`
+	}
+	raw := json.RawMessage(`{"type":"object","properties":{"query":{"type":"string"}},"required":["query"]}`)
+
+	assert.NoError(t, Validate(raw, args{Query: "hello"}))
+	assert.Error(t, Validate(raw, map[string]any{}))
+	assert.NoError(t, Validate(nil, "anything"))
+	assert.ErrorContains(t, Validate(json.RawMessage(`[`), "x"), "invalid schema")
+}
+
+func TestTypeAndRequired(t *testing.T) {
+	assert.Equal(t, "string", Type(map[string]any{"type": "string"}))
+	assert.Equal(t, "integer", Type(map[string]any{"type": []any{"null", "integer"}}))
+	assert.Equal(t, "", Type(map[string]any{}))
+
+	assert.Equal(t, map[string]bool{"a": true, "b": true}, Required(map[string]any{"required": []any{"a", "b", 3}}))
+	assert.Empty(t, Required(map[string]any{}))
+}
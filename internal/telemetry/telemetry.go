@@ -0,0 +1,91 @@
+// Package telemetry holds the OpenTelemetry instrumentation shared by the client, tool and
+// prompt packages: the tracer, span attribute keys and trace context propagation.
+package telemetry
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the instrumentation scope of the spans.
+const TracerName = "github.com/akihiro-fukuchi/langchaingo-mcp-adapters"
+
+// Span attribute keys.
+const (
+	ServerNameKey    = attribute.Key("mcp.server.name")
+	TransportKey     = attribute.Key("mcp.transport")
+	MethodNameKey    = attribute.Key("mcp.method.name")
+	ToolNameKey      = attribute.Key("gen_ai.tool.name")
+	PromptNameKey    = attribute.Key("mcp.prompt.name")
+	ArgumentsSizeKey = attribute.Key("mcp.request.arguments.size")
+	ResultSizeKey    = attribute.Key("mcp.response.result.size")
+	IsErrorKey       = attribute.Key("mcp.response.is_error")
+	ToolCountKey     = attribute.Key("mcp.tools.count")
+	MessageCountKey  = attribute.Key("mcp.prompt.messages.count")
+
+	OriginalResultSizeKey = attribute.Key("mcp.response.result.original_size")
+	TruncationKey         = attribute.Key("mcp.response.result.truncation")
+)
+
+// Config selects the tracer provider and propagator. Nil fields use the global ones.
+type Config struct {
+	TracerProvider trace.TracerProvider
+	Propagator     propagation.TextMapPropagator
+}
+
+// Tracer returns the tracer of the configured provider.
+func (c Config) Tracer() trace.Tracer {
+	tp := c.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tp.Tracer(TracerName)
+}
+
+func (c Config) propagator() propagation.TextMapPropagator {
+	if c.Propagator != nil {
+		return c.Propagator
+	}
+	return otel.GetTextMapPropagator()
+}
+
+// Headers returns the trace context of ctx as HTTP headers, such as traceparent.
+func (c Config) Headers(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	c.propagator().Inject(ctx, carrier)
+	return carrier
+}
+
+// InjectMeta adds the trace context of ctx to the _meta of a request, creating meta if
+// needed. It returns meta unchanged if there is no trace context to propagate.
+func (c Config) InjectMeta(ctx context.Context, meta *mcp.Meta) *mcp.Meta {
+	headers := c.Headers(ctx)
+	if len(headers) == 0 {
+		return meta
+	}
+	if meta == nil {
+		meta = &mcp.Meta{}
+	}
+	if meta.AdditionalFields == nil {
+		meta.AdditionalFields = make(map[string]any, len(headers))
+	}
+	for key, value := range headers {
+		meta.AdditionalFields[key] = value
+	}
+	return meta
+}
+
+// End records err, if any, on span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,124 @@
+// Package metrics records tool calls and the session lifecycle of MCP clients, with
+// OpenTelemetry and Prometheus implementations of Recorder.
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Outcome classifies the result of a tool call.
+type Outcome string
+
+const (
+	OutcomeSuccess        Outcome = "success"         // The tool returned a result
+	OutcomeToolError      Outcome = "tool_error"      // The tool returned a result with isError set
+	OutcomeTransportError Outcome = "transport_error" // The request failed, e.g. the connection was lost
+)
+
+// OtherLabel replaces server and tool names beyond the cardinality limits.
+const OtherLabel = "other"
+
+// Recorder receives the measurements of MCP clients and tools.
+type Recorder interface {
+	// RecordToolCall records a tools/call request to serverName that took duration.
+	RecordToolCall(ctx context.Context, serverName, toolName string, outcome Outcome, duration time.Duration)
+	// RecordSessionOpened records that a session with serverName was initialized.
+	RecordSessionOpened(ctx context.Context, serverName string)
+	// RecordSessionClosed records that a session with serverName was closed.
+	RecordSessionClosed(ctx context.Context, serverName string)
+	// RecordReconnect records an attempt to reconnect to serverName.
+	RecordReconnect(ctx context.Context, serverName string)
+}
+
+// Option configures the labels of a Recorder.
+type Option func(*labeler)
+
+// WithAllowedTools limits the tool label to names; other tools are recorded as OtherLabel.
+func WithAllowedTools(names ...string) Option {
+	return func(l *labeler) {
+		l.allowedTools = make(map[string]bool, len(names))
+		for _, name := range names {
+			l.allowedTools[name] = true
+		}
+	}
+}
+
+// WithMaxTools limits the number of distinct tool label values to n. Tools seen after the
+// first n are recorded as OtherLabel.
+func WithMaxTools(n int) Option {
+	return func(l *labeler) {
+		l.maxTools = n
+	}
+}
+
+// WithMaxServers limits the number of distinct server label values to n. Servers seen after
+// the first n are recorded as OtherLabel.
+func WithMaxServers(n int) Option {
+	return func(l *labeler) {
+		l.maxServers = n
+	}
+}
+
+// WithoutToolLabel records tool calls per server only, leaving the tool label empty.
+func WithoutToolLabel() Option {
+	return func(l *labeler) {
+		l.dropTool = true
+	}
+}
+
+// labeler applies the cardinality limits to server and tool names.
+type labeler struct {
+	allowedTools map[string]bool // Allowed tool names, nil allows every tool
+	maxTools     int             // Maximum number of distinct tool names, 0 for no limit
+	maxServers   int             // Maximum number of distinct server names, 0 for no limit
+	dropTool     bool            // Whether the tool label is left empty
+
+	mu      sync.Mutex
+	tools   map[string]bool // Tool names recorded so far
+	servers map[string]bool // Server names recorded so far
+}
+
+func newLabeler(opts []Option) *labeler {
+	l := &labeler{
+		tools:   make(map[string]bool),
+		servers: make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// server returns the label value for serverName.
+func (l *labeler) server(serverName string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return limit(l.servers, l.maxServers, serverName)
+}
+
+// tool returns the label value for toolName.
+func (l *labeler) tool(toolName string) string {
+	if l.dropTool {
+		return ""
+	}
+	if l.allowedTools != nil && !l.allowedTools[toolName] {
+		return OtherLabel
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return limit(l.tools, l.maxTools, toolName)
+}
+
+// limit returns name if it was seen before or fewer than maxValues names were, OtherLabel otherwise.
+func limit(seen map[string]bool, maxValues int, name string) string {
+	if maxValues <= 0 || seen[name] {
+		return name
+	}
+	if len(seen) >= maxValues {
+		return OtherLabel
+	}
+	seen[name] = true
+	return name
+}
@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabeler(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		tools    []string
+		expected []string
+	}{
+		{
+			name:     "No limits",
+			tools:    []string{"add", "multiply", "divide"},
+			expected: []string{"add", "multiply", "divide"},
+		},
+		{
+			name:     "Allowed tools",
+			opts:     []Option{WithAllowedTools("add", "divide")},
+			tools:    []string{"add", "multiply", "divide"},
+			expected: []string{"add", OtherLabel, "divide"},
+		},
+		{
+			name:     "Max tools keeps the first tools seen",
+			opts:     []Option{WithMaxTools(2)},
+			tools:    []string{"add", "multiply", "divide", "add"},
+			expected: []string{"add", "multiply", OtherLabel, "add"},
+		},
+		{
+			name:     "Without tool label",
+			opts:     []Option{WithoutToolLabel()},
+			tools:    []string{"add"},
+			expected: []string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLabeler(tt.opts)
+			labels := make([]string, 0, len(tt.tools))
+			for _, tool := range tt.tools {
+				labels = append(labels, l.tool(tool))
+			}
+			assert.Equal(t, tt.expected, labels)
+		})
+	}
+}
+
+func TestLabeler_MaxServers(t *testing.T) {
+	l := newLabeler([]Option{WithMaxServers(1)})
+
+	assert.Equal(t, "math", l.server("math"))
+	assert.Equal(t, OtherLabel, l.server("weather"))
+	assert.Equal(t, "math", l.server("math"))
+}
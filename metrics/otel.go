@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// MeterName is the instrumentation scope of the OpenTelemetry instruments.
+const MeterName = "github.com/akihiro-fukuchi/langchaingo-mcp-adapters"
+
+// Attribute keys of the OpenTelemetry instruments.
+const (
+	ServerNameKey = attribute.Key("mcp.server.name")
+	ToolNameKey   = attribute.Key("gen_ai.tool.name")
+	OutcomeKey    = attribute.Key("mcp.outcome")
+	ErrorTypeKey  = attribute.Key("error.type")
+)
+
+// OTelRecorder records measurements with OpenTelemetry instruments:
+//
+//   - mcp.client.tool.calls: tool calls by server, tool and outcome
+//   - mcp.client.tool.errors: failed tool calls by server, tool and error type (tool or transport)
+//   - mcp.client.tool.duration: tool call latency in seconds by server and tool
+//   - mcp.client.sessions.active: open sessions by server
+//   - mcp.client.reconnects: reconnect attempts by server
+type OTelRecorder struct {
+	labels *labeler
+
+	calls      metric.Int64Counter
+	errors     metric.Int64Counter
+	duration   metric.Float64Histogram
+	sessions   metric.Int64UpDownCounter
+	reconnects metric.Int64Counter
+}
+
+var _ Recorder = (*OTelRecorder)(nil)
+
+// NewOTelRecorder creates the instruments with a meter of mp. If mp is nil, the global
+// meter provider is used.
+func NewOTelRecorder(mp metric.MeterProvider, opts ...Option) (*OTelRecorder, error) {
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	meter := mp.Meter(MeterName)
+	r := &OTelRecorder{labels: newLabeler(opts)}
+
+	var err error
+	if r.calls, err = meter.Int64Counter("mcp.client.tool.calls",
+		metric.WithDescription("Number of MCP tool calls"), metric.WithUnit("{call}")); err != nil {
+		return nil, fmt.Errorf("failed to create tool calls counter: %w", err)
+	}
+	if r.errors, err = meter.Int64Counter("mcp.client.tool.errors",
+		metric.WithDescription("Number of failed MCP tool calls"), metric.WithUnit("{call}")); err != nil {
+		return nil, fmt.Errorf("failed to create tool errors counter: %w", err)
+	}
+	if r.duration, err = meter.Float64Histogram("mcp.client.tool.duration",
+		metric.WithDescription("Duration of MCP tool calls"), metric.WithUnit("s")); err != nil {
+		return nil, fmt.Errorf("failed to create tool duration histogram: %w", err)
+	}
+	if r.sessions, err = meter.Int64UpDownCounter("mcp.client.sessions.active",
+		metric.WithDescription("Number of open MCP sessions"), metric.WithUnit("{session}")); err != nil {
+		return nil, fmt.Errorf("failed to create active sessions counter: %w", err)
+	}
+	if r.reconnects, err = meter.Int64Counter("mcp.client.reconnects",
+		metric.WithDescription("Number of attempts to reconnect to MCP servers"), metric.WithUnit("{reconnect}")); err != nil {
+		return nil, fmt.Errorf("failed to create reconnects counter: %w", err)
+	}
+	return r, nil
+}
+
+// RecordToolCall implements Recorder.
+func (r *OTelRecorder) RecordToolCall(ctx context.Context, serverName, toolName string, outcome Outcome, duration time.Duration) {
+	server := ServerNameKey.String(r.labels.server(serverName))
+	tool := ToolNameKey.String(r.labels.tool(toolName))
+	r.calls.Add(ctx, 1, metric.WithAttributes(server, tool, OutcomeKey.String(string(outcome))))
+	r.duration.Record(ctx, duration.Seconds(), metric.WithAttributes(server, tool))
+	if errorType := errorType(outcome); errorType != "" {
+		r.errors.Add(ctx, 1, metric.WithAttributes(server, tool, ErrorTypeKey.String(errorType)))
+	}
+}
+
+// RecordSessionOpened implements Recorder.
+func (r *OTelRecorder) RecordSessionOpened(ctx context.Context, serverName string) {
+	r.sessions.Add(ctx, 1, metric.WithAttributes(ServerNameKey.String(r.labels.server(serverName))))
+}
+
+// RecordSessionClosed implements Recorder.
+func (r *OTelRecorder) RecordSessionClosed(ctx context.Context, serverName string) {
+	r.sessions.Add(ctx, -1, metric.WithAttributes(ServerNameKey.String(r.labels.server(serverName))))
+}
+
+// RecordReconnect implements Recorder.
+func (r *OTelRecorder) RecordReconnect(ctx context.Context, serverName string) {
+	r.reconnects.Add(ctx, 1, metric.WithAttributes(ServerNameKey.String(r.labels.server(serverName))))
+}
+
+// errorType returns the error type label of a failed call, or "" if the call succeeded.
+func errorType(outcome Outcome) string {
+	switch outcome {
+	case OutcomeToolError:
+		return "tool"
+	case OutcomeTransportError:
+		return "transport"
+	default:
+		return ""
+	}
+}
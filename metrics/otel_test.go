@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func collect(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	aggregations := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			aggregations[m.Name] = m.Data
+		}
+	}
+	return aggregations
+}
+
+func TestOTelRecorder(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	recorder, err := NewOTelRecorder(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)), WithMaxTools(1))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	recorder.RecordSessionOpened(ctx, "math")
+	recorder.RecordToolCall(ctx, "math", "add", OutcomeSuccess, 10*time.Millisecond)
+	recorder.RecordToolCall(ctx, "math", "add", OutcomeToolError, 20*time.Millisecond)
+	recorder.RecordToolCall(ctx, "math", "divide", OutcomeTransportError, 30*time.Millisecond)
+	recorder.RecordReconnect(ctx, "math")
+	recorder.RecordSessionClosed(ctx, "math")
+	recorder.RecordSessionOpened(ctx, "math")
+
+	aggregations := collect(t, reader)
+	server := ServerNameKey.String("math")
+
+	calls := aggregations["mcp.client.tool.calls"].(metricdata.Sum[int64])
+	assert.ElementsMatch(t, []metricdata.DataPoint[int64]{
+		{Attributes: attribute.NewSet(server, ToolNameKey.String("add"), OutcomeKey.String("success")), Value: 1},
+		{Attributes: attribute.NewSet(server, ToolNameKey.String("add"), OutcomeKey.String("tool_error")), Value: 1},
+		{Attributes: attribute.NewSet(server, ToolNameKey.String(OtherLabel), OutcomeKey.String("transport_error")), Value: 1},
+	}, withoutTimestamps(calls.DataPoints))
+
+	errors := aggregations["mcp.client.tool.errors"].(metricdata.Sum[int64])
+	assert.ElementsMatch(t, []metricdata.DataPoint[int64]{
+		{Attributes: attribute.NewSet(server, ToolNameKey.String("add"), ErrorTypeKey.String("tool")), Value: 1},
+		{Attributes: attribute.NewSet(server, ToolNameKey.String(OtherLabel), ErrorTypeKey.String("transport")), Value: 1},
+	}, withoutTimestamps(errors.DataPoints))
+
+	duration := aggregations["mcp.client.tool.duration"].(metricdata.Histogram[float64])
+	require.Len(t, duration.DataPoints, 2)
+	for _, point := range duration.DataPoints {
+		tool, _ := point.Attributes.Value(ToolNameKey)
+		switch tool.AsString() {
+		case "add":
+			assert.Equal(t, uint64(2), point.Count)
+			assert.InDelta(t, 0.03, point.Sum, 1e-9)
+		case OtherLabel:
+			assert.Equal(t, uint64(1), point.Count)
+		default:
+			t.Errorf("unexpected tool label %q", tool.AsString())
+		}
+	}
+
+	sessions := aggregations["mcp.client.sessions.active"].(metricdata.Sum[int64])
+	require.Len(t, sessions.DataPoints, 1)
+	assert.Equal(t, int64(1), sessions.DataPoints[0].Value)
+
+	reconnects := aggregations["mcp.client.reconnects"].(metricdata.Sum[int64])
+	require.Len(t, reconnects.DataPoints, 1)
+	assert.Equal(t, int64(1), reconnects.DataPoints[0].Value)
+}
+
+func withoutTimestamps(points []metricdata.DataPoint[int64]) []metricdata.DataPoint[int64] {
+	stripped := make([]metricdata.DataPoint[int64], 0, len(points))
+	for _, point := range points {
+		stripped = append(stripped, metricdata.DataPoint[int64]{Attributes: point.Attributes, Value: point.Value})
+	}
+	return stripped
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusCollector records measurements as Prometheus metrics. Register it with a
+// prometheus.Registerer to export:
+//
+//   - mcp_client_tool_calls_total{server,tool,outcome}
+//   - mcp_client_tool_errors_total{server,tool,type}, where type is tool or transport
+//   - mcp_client_tool_call_duration_seconds{server,tool}
+//   - mcp_client_sessions_active{server}
+//   - mcp_client_reconnects_total{server}
+type PrometheusCollector struct {
+	labels *labeler
+
+	calls      *prometheus.CounterVec
+	errors     *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+	sessions   *prometheus.GaugeVec
+	reconnects *prometheus.CounterVec
+}
+
+var (
+	_ Recorder             = (*PrometheusCollector)(nil)
+	_ prometheus.Collector = (*PrometheusCollector)(nil)
+)
+
+// NewPrometheusCollector creates a collector with the default histogram buckets.
+func NewPrometheusCollector(opts ...Option) *PrometheusCollector {
+	return &PrometheusCollector{
+		labels: newLabeler(opts),
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mcp_client_tool_calls_total",
+			Help: "Number of MCP tool calls.",
+		}, []string{"server", "tool", "outcome"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mcp_client_tool_errors_total",
+			Help: "Number of failed MCP tool calls.",
+		}, []string{"server", "tool", "type"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "mcp_client_tool_call_duration_seconds",
+			Help:    "Duration of MCP tool calls.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"server", "tool"}),
+		sessions: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "mcp_client_sessions_active",
+			Help: "Number of open MCP sessions.",
+		}, []string{"server"}),
+		reconnects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mcp_client_reconnects_total",
+			Help: "Number of attempts to reconnect to MCP servers.",
+		}, []string{"server"}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *PrometheusCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.calls.Describe(ch)
+	c.errors.Describe(ch)
+	c.duration.Describe(ch)
+	c.sessions.Describe(ch)
+	c.reconnects.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *PrometheusCollector) Collect(ch chan<- prometheus.Metric) {
+	c.calls.Collect(ch)
+	c.errors.Collect(ch)
+	c.duration.Collect(ch)
+	c.sessions.Collect(ch)
+	c.reconnects.Collect(ch)
+}
+
+// RecordToolCall implements Recorder.
+func (c *PrometheusCollector) RecordToolCall(_ context.Context, serverName, toolName string, outcome Outcome, duration time.Duration) {
+	server := c.labels.server(serverName)
+	tool := c.labels.tool(toolName)
+	c.calls.WithLabelValues(server, tool, string(outcome)).Inc()
+	c.duration.WithLabelValues(server, tool).Observe(duration.Seconds())
+	if errorType := errorType(outcome); errorType != "" {
+		c.errors.WithLabelValues(server, tool, errorType).Inc()
+	}
+}
+
+// RecordSessionOpened implements Recorder.
+func (c *PrometheusCollector) RecordSessionOpened(_ context.Context, serverName string) {
+	c.sessions.WithLabelValues(c.labels.server(serverName)).Inc()
+}
+
+// RecordSessionClosed implements Recorder.
+func (c *PrometheusCollector) RecordSessionClosed(_ context.Context, serverName string) {
+	c.sessions.WithLabelValues(c.labels.server(serverName)).Dec()
+}
+
+// RecordReconnect implements Recorder.
+func (c *PrometheusCollector) RecordReconnect(_ context.Context, serverName string) {
+	c.reconnects.WithLabelValues(c.labels.server(serverName)).Inc()
+}
@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusCollector(t *testing.T) {
+	collector := NewPrometheusCollector(WithAllowedTools("add"))
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(collector))
+	ctx := context.Background()
+
+	collector.RecordSessionOpened(ctx, "math")
+	collector.RecordToolCall(ctx, "math", "add", OutcomeSuccess, 10*time.Millisecond)
+	collector.RecordToolCall(ctx, "math", "add", OutcomeToolError, 20*time.Millisecond)
+	collector.RecordToolCall(ctx, "math", "divide", OutcomeTransportError, 30*time.Millisecond)
+	collector.RecordReconnect(ctx, "math")
+
+	expected := `
+# HELP mcp_client_tool_calls_total Number of MCP tool calls.
+# TYPE mcp_client_tool_calls_total counter
+mcp_client_tool_calls_total{outcome="success",server="math",tool="add"} 1
+mcp_client_tool_calls_total{outcome="tool_error",server="math",tool="add"} 1
+mcp_client_tool_calls_total{outcome="transport_error",server="math",tool="other"} 1
+# HELP mcp_client_tool_errors_total Number of failed MCP tool calls.
+# TYPE mcp_client_tool_errors_total counter
+mcp_client_tool_errors_total{server="math",tool="add",type="tool"} 1
+mcp_client_tool_errors_total{server="math",tool="other",type="transport"} 1
+# HELP mcp_client_sessions_active Number of open MCP sessions.
+# TYPE mcp_client_sessions_active gauge
+mcp_client_sessions_active{server="math"} 1
+# HELP mcp_client_reconnects_total Number of attempts to reconnect to MCP servers.
+# TYPE mcp_client_reconnects_total counter
+mcp_client_reconnects_total{server="math"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"mcp_client_tool_calls_total", "mcp_client_tool_errors_total", "mcp_client_sessions_active", "mcp_client_reconnects_total"))
+	assert.Equal(t, 2, testutil.CollectAndCount(collector, "mcp_client_tool_call_duration_seconds"))
+}
@@ -3,12 +3,82 @@ package prompt
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tmc/langchaingo/callbacks"
 	"github.com/tmc/langchaingo/llms"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/internal/telemetry"
 )
 
+// Option configures how prompts are loaded.
+type Option func(*options)
+
+type options struct {
+	serverName string            // Name of the server providing the prompt, used in spans
+	telemetry  telemetry.Config  // Tracing configuration
+	logger     *slog.Logger      // Logger of the loader
+	callbacks  callbacks.Handler // Optional handler notified of loaded prompts
+	timeout    time.Duration     // Optional timeout of the prompts/get request
+}
+
+// PromptLoad describes a prompt fetched by LoadMCPPrompt.
+type PromptLoad struct {
+	ServerName string // Empty unless WithServerName is set
+	PromptName string
+	Arguments  map[string]string
+	Messages   []llms.ChatMessage
+}
+
+// PromptCallbackHandler can be implemented by a callbacks.Handler passed to
+// WithCallbacksHandler to observe the prompts that are loaded.
+type PromptCallbackHandler interface {
+	HandlePromptLoad(ctx context.Context, load PromptLoad)
+}
+
+// WithCallbacksHandler notifies handler of every loaded prompt if it implements
+// PromptCallbackHandler.
+func WithCallbacksHandler(handler callbacks.Handler) Option {
+	return func(o *options) {
+		o.callbacks = handler
+	}
+}
+
+// WithLogger sets the logger of the loader. Defaults to slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithServerName records the name of the server providing the prompt in traces.
+func WithServerName(serverName string) Option {
+	return func(o *options) {
+		o.serverName = serverName
+	}
+}
+
+// WithTimeout limits the duration of the prompts/get request. Zero, the default, sets no limit
+// beyond the context passed to LoadMCPPrompt.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithTracerProvider sets the OpenTelemetry tracer provider of the prompts/get spans.
+// Defaults to the global tracer provider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(o *options) {
+		o.telemetry.TracerProvider = tp
+	}
+}
+
 // convertMCPPromptMessageToLangchainMessage converts an MCP prompt message to a LangchainGo message.
 func convertMCPPromptMessageToLangchainMessage(message mcp.PromptMessage) (llms.ChatMessage, error) {
 	switch content := message.Content.(type) {
@@ -37,7 +107,32 @@ func convertMCPPromptMessageToLangchainMessage(message mcp.PromptMessage) (llms.
 }
 
 // LoadMCPPrompt fetches an MCP prompt by name and converts its messages to LangchainGo format.
-func LoadMCPPrompt(ctx context.Context, mcpClient client.MCPClient, name string, arguments map[string]string) ([]llms.ChatMessage, error) {
+func LoadMCPPrompt(ctx context.Context, mcpClient client.MCPClient, name string, arguments map[string]string, opts ...Option) (_ []llms.ChatMessage, err error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.logger == nil {
+		o.logger = slog.Default()
+	}
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+	attributes := []attribute.KeyValue{
+		telemetry.MethodNameKey.String(string(mcp.MethodPromptsGet)),
+		telemetry.PromptNameKey.String(name),
+	}
+	if o.serverName != "" {
+		attributes = append(attributes, telemetry.ServerNameKey.String(o.serverName))
+	}
+	ctx, span := o.telemetry.Tracer().Start(ctx, string(mcp.MethodPromptsGet)+" "+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+	defer func() { telemetry.End(span, err) }()
+
 	request := mcp.GetPromptRequest{}
 	request.Params.Name = name
 	request.Params.Arguments = arguments // mcp-go expects map[string]string
@@ -52,12 +147,21 @@ func LoadMCPPrompt(ctx context.Context, mcpClient client.MCPClient, name string,
 		lcMessage, err := convertMCPPromptMessageToLangchainMessage(mcpMessage)
 		if err != nil {
 			// Skip unsupported messages for now, or return error depending on desired behavior
-			// For now, let's skip and log (or just skip)
-			// fmt.Printf("Skipping unsupported message type in prompt %s: %v\n", name, err)
+			o.logger.Debug("LoadMCPPrompt skipping unsupported message", "prompt_name", name, "error", err)
 			continue
 		}
 		langchainMessages = append(langchainMessages, lcMessage)
 	}
+	span.SetAttributes(telemetry.MessageCountKey.Int(len(langchainMessages)))
+
+	if handler, ok := o.callbacks.(PromptCallbackHandler); ok {
+		handler.HandlePromptLoad(ctx, PromptLoad{
+			ServerName: o.serverName,
+			PromptName: name,
+			Arguments:  arguments,
+			Messages:   langchainMessages,
+		})
+	}
 
 	return langchainMessages, nil
 }
@@ -10,7 +10,11 @@ import (
 	. "github.com/ovechkin-dm/mockio/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/callbacks"
 	"github.com/tmc/langchaingo/llms"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestConvertMCPPromptMessageToLangchainMessage_Text(t *testing.T) {
@@ -240,3 +244,59 @@ func TestLoadMCPPrompt_ConversionErrorSkipped(t *testing.T) {
 
 	Verify(mockClient, Once()).GetPrompt(Any[context.Context](), Equal(expectedRequest))
 }
+
+func TestLoadMCPPrompt_Tracing(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	When(mockClient.GetPrompt(Any[context.Context](), Any[mcp.GetPromptRequest]())).
+		ThenReturn(&mcp.GetPromptResult{Messages: []mcp.PromptMessage{
+			{Role: mcp.RoleUser, Content: mcp.TextContent{Text: "Hello"}},
+		}}, nil)
+
+	_, err := LoadMCPPrompt(context.Background(), mockClient, "greet", nil, WithServerName("prompts"), WithTracerProvider(tp))
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "prompts/get greet", spans[0].Name)
+	assert.Contains(t, spans[0].Attributes, attribute.String("mcp.server.name", "prompts"))
+	assert.Contains(t, spans[0].Attributes, attribute.String("mcp.prompt.name", "greet"))
+	assert.Contains(t, spans[0].Attributes, attribute.Int("mcp.prompt.messages.count", 1))
+}
+
+type FakePromptCallbackHandler struct {
+	callbacks.SimpleHandler
+	loads []PromptLoad
+}
+
+func (h *FakePromptCallbackHandler) HandlePromptLoad(_ context.Context, load PromptLoad) {
+	h.loads = append(h.loads, load)
+}
+
+func TestLoadMCPPrompt_CallbacksHandler(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+	handler := &FakePromptCallbackHandler{}
+
+	args := map[string]string{"topic": "go"}
+	When(mockClient.GetPrompt(Any[context.Context](), Any[mcp.GetPromptRequest]())).
+		ThenReturn(&mcp.GetPromptResult{Messages: []mcp.PromptMessage{
+			{Role: mcp.RoleUser, Content: mcp.TextContent{Text: "Tell me about go"}},
+		}}, nil)
+
+	_, err := LoadMCPPrompt(context.Background(), mockClient, "explain", args,
+		WithServerName("docs"),
+		WithCallbacksHandler(handler),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, []PromptLoad{{
+		ServerName: "docs",
+		PromptName: "explain",
+		Arguments:  args,
+		Messages:   []llms.ChatMessage{llms.HumanChatMessage{Content: "Tell me about go"}},
+	}}, handler.loads)
+}
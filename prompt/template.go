@@ -0,0 +1,107 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+)
+
+// Template is a LangchainGo prompt template backed by an MCP prompt. Its input variables are
+// the arguments declared by the prompt, and formatting fetches the prompt with prompts/get.
+// It can be used wherever a prompts.ChatPromptTemplate is, e.g. in chains.NewLLMChain.
+type Template struct {
+	mcpClient client.MCPClient
+	prompt    mcp.Prompt
+	opts      []Option
+}
+
+var (
+	_ prompts.FormatPrompter   = Template{}
+	_ prompts.Formatter        = Template{}
+	_ prompts.MessageFormatter = Template{}
+)
+
+// NewMCPPromptTemplate creates a template for an MCP prompt returned by prompts/list.
+// The prompts/get requests made while formatting are made with opts. LangchainGo formats
+// templates without a context, so they use context.Background(); set WithTimeout to bound them.
+func NewMCPPromptTemplate(mcpClient client.MCPClient, prompt mcp.Prompt, opts ...Option) Template {
+	return Template{mcpClient: mcpClient, prompt: prompt, opts: opts}
+}
+
+// LoadMCPPromptTemplate looks up an MCP prompt by name, following the pagination of
+// prompts/list, and creates a template for it. ctx is only used for the lookup.
+func LoadMCPPromptTemplate(ctx context.Context, mcpClient client.MCPClient, name string, opts ...Option) (Template, error) {
+	request := mcp.ListPromptsRequest{}
+	for {
+		result, err := mcpClient.ListPromptsByPage(ctx, request)
+		if err != nil {
+			return Template{}, fmt.Errorf("failed to list MCP prompts: %w", err)
+		}
+		for _, prompt := range result.Prompts {
+			if prompt.Name == name {
+				return NewMCPPromptTemplate(mcpClient, prompt, opts...), nil
+			}
+		}
+		if result.NextCursor == "" {
+			return Template{}, fmt.Errorf("MCP prompt '%s' not found", name)
+		}
+		request.Params.Cursor = result.NextCursor
+	}
+}
+
+// FormatPrompt fetches the MCP prompt with the declared arguments taken from values.
+// Values that are not strings are formatted with fmt.Sprint; other values are ignored.
+func (t Template) FormatPrompt(values map[string]any) (llms.PromptValue, error) { //nolint:ireturn
+	arguments := make(map[string]string, len(t.prompt.Arguments))
+	for _, argument := range t.prompt.Arguments {
+		value, ok := values[argument.Name]
+		if !ok {
+			if argument.Required {
+				return nil, fmt.Errorf("missing value for required argument '%s' of MCP prompt '%s'", argument.Name, t.prompt.Name)
+			}
+			continue
+		}
+		if s, ok := value.(string); ok {
+			arguments[argument.Name] = s
+		} else {
+			arguments[argument.Name] = fmt.Sprint(value)
+		}
+	}
+
+	messages, err := LoadMCPPrompt(context.Background(), t.mcpClient, t.prompt.Name, arguments, t.opts...)
+	if err != nil {
+		return nil, err
+	}
+	return prompts.ChatPromptValue(messages), nil
+}
+
+// Format formats the MCP prompt and returns its messages as a string.
+func (t Template) Format(values map[string]any) (string, error) {
+	promptValue, err := t.FormatPrompt(values)
+	if err != nil {
+		return "", err
+	}
+	return promptValue.String(), nil
+}
+
+// FormatMessages formats the MCP prompt and returns its messages.
+func (t Template) FormatMessages(values map[string]any) ([]llms.ChatMessage, error) {
+	promptValue, err := t.FormatPrompt(values)
+	if err != nil {
+		return nil, err
+	}
+	return promptValue.Messages(), nil
+}
+
+// GetInputVariables returns the names of the arguments declared by the MCP prompt.
+func (t Template) GetInputVariables() []string {
+	variables := make([]string, 0, len(t.prompt.Arguments))
+	for _, argument := range t.prompt.Arguments {
+		variables = append(variables, argument.Name)
+	}
+	return variables
+}
@@ -0,0 +1,138 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	. "github.com/ovechkin-dm/mockio/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+)
+
+type FakeModel struct {
+	messages []llms.MessageContent
+}
+
+func (m *FakeModel) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	m.messages = messages
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "Bonjour"}}}, nil
+}
+
+func (m *FakeModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func translatePrompt() mcp.Prompt {
+	return mcp.NewPrompt("translate",
+		mcp.WithArgument("text", mcp.RequiredArgument()),
+		mcp.WithArgument("language"),
+	)
+}
+
+func TestLoadMCPPromptTemplate(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+	firstPage := &mcp.ListPromptsResult{Prompts: []mcp.Prompt{mcp.NewPrompt("summarize")}}
+	firstPage.NextCursor = "page-2"
+	secondPageRequest := mcp.ListPromptsRequest{}
+	secondPageRequest.Params.Cursor = "page-2"
+	When(mockClient.ListPromptsByPage(Any[context.Context](), Equal(mcp.ListPromptsRequest{}))).ThenReturn(firstPage, nil)
+	When(mockClient.ListPromptsByPage(Any[context.Context](), Equal(secondPageRequest))).
+		ThenReturn(&mcp.ListPromptsResult{Prompts: []mcp.Prompt{translatePrompt()}}, nil)
+
+	template, err := LoadMCPPromptTemplate(context.Background(), mockClient, "translate")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"text", "language"}, template.GetInputVariables(), "The prompt is found on the second page")
+
+	_, err = LoadMCPPromptTemplate(context.Background(), mockClient, "unknown")
+	assert.ErrorContains(t, err, "not found")
+	Verify(mockClient, Times(2)).ListPromptsByPage(Any[context.Context](), Equal(secondPageRequest))
+}
+
+func TestLoadMCPPromptTemplate_CancelledContext(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+	When(mockClient.ListPromptsByPage(Any[context.Context](), Any[mcp.ListPromptsRequest]())).
+		ThenReturn(&mcp.ListPromptsResult{Prompts: []mcp.Prompt{translatePrompt()}}, nil)
+	var hasDeadline bool
+	When(mockClient.GetPrompt(Any[context.Context](), Any[mcp.GetPromptRequest]())).
+		ThenAnswer(func(args []any) []any {
+			ctx := args[0].(context.Context)
+			_, hasDeadline = ctx.Deadline()
+			return []any{&mcp.GetPromptResult{}, ctx.Err()}
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	template, err := LoadMCPPromptTemplate(ctx, mockClient, "translate", WithTimeout(time.Minute))
+	require.NoError(t, err)
+	cancel()
+
+	_, err = template.FormatPrompt(map[string]any{"text": "Hello"})
+	require.NoError(t, err, "Formatting does not use the context of the loader")
+	assert.True(t, hasDeadline, "WithTimeout bounds prompts/get")
+}
+
+func TestTemplate_FormatPrompt(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+
+	expectedRequest := mcp.GetPromptRequest{}
+	expectedRequest.Params.Name = "translate"
+	expectedRequest.Params.Arguments = map[string]string{"text": "Hello", "language": "French"}
+	When(mockClient.GetPrompt(Any[context.Context](), Equal(expectedRequest))).
+		ThenReturn(&mcp.GetPromptResult{Messages: []mcp.PromptMessage{
+			{Role: mcp.RoleUser, Content: mcp.TextContent{Text: "Translate 'Hello' to French."}},
+		}}, nil)
+
+	template := NewMCPPromptTemplate(mockClient, translatePrompt())
+
+	messages, err := template.FormatMessages(map[string]any{"text": "Hello", "language": "French", "history": "ignored"})
+	require.NoError(t, err)
+	assert.Equal(t, []llms.ChatMessage{llms.HumanChatMessage{Content: "Translate 'Hello' to French."}}, messages)
+
+	text, err := template.Format(map[string]any{"text": "Hello", "language": "French"})
+	require.NoError(t, err)
+	assert.Equal(t, "Human: Translate 'Hello' to French.", text)
+
+	_, err = template.FormatPrompt(map[string]any{"language": "French"})
+	assert.ErrorContains(t, err, "missing value for required argument 'text'")
+}
+
+func TestTemplate_FormatPrompt_Error(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+	When(mockClient.GetPrompt(Any[context.Context](), Any[mcp.GetPromptRequest]())).ThenReturn(nil, errors.New("server gone"))
+
+	template := NewMCPPromptTemplate(mockClient, translatePrompt())
+	_, err := template.FormatPrompt(map[string]any{"text": 42})
+
+	assert.ErrorContains(t, err, "server gone")
+	expectedRequest := mcp.GetPromptRequest{}
+	expectedRequest.Params.Name = "translate"
+	expectedRequest.Params.Arguments = map[string]string{"text": "42"}
+	Verify(mockClient, Once()).GetPrompt(Any[context.Context](), Equal(expectedRequest))
+}
+
+func TestTemplate_LLMChain(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+	When(mockClient.GetPrompt(Any[context.Context](), Any[mcp.GetPromptRequest]())).
+		ThenReturn(&mcp.GetPromptResult{Messages: []mcp.PromptMessage{
+			{Role: mcp.RoleUser, Content: mcp.TextContent{Text: "Translate 'Hello' to French."}},
+		}}, nil)
+
+	model := &FakeModel{}
+	chain := chains.NewLLMChain(model, NewMCPPromptTemplate(mockClient, translatePrompt()))
+
+	outputs, err := chains.Call(context.Background(), chain, map[string]any{"text": "Hello", "language": "French"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Bonjour", outputs["text"])
+	require.Len(t, model.messages, 1)
+	assert.Equal(t, llms.TextParts(llms.ChatMessageTypeHuman, "Human: Translate 'Hello' to French."), model.messages[0], "LLMChain sends the prompt value as a string")
+}
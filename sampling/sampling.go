@@ -0,0 +1,269 @@
+package sampling
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// DefaultModelName is reported to servers when no model name is configured.
+const DefaultModelName = "langchaingo"
+
+// ErrRejected is returned to the server when the approval hook rejects a sampling request.
+var ErrRejected = errors.New("sampling request rejected")
+
+// ApprovalFunc is called before a sampling request is executed.
+// Returning false rejects the request; returning an error aborts it.
+type ApprovalFunc func(ctx context.Context, request mcp.CreateMessageRequest) (bool, error)
+
+// namedModel is a model that can be selected through model preference hints.
+type namedModel struct {
+	name  string
+	model llms.Model
+}
+
+// Handler answers sampling/createMessage requests from MCP servers using langchaingo models.
+type Handler struct {
+	models  []namedModel // The first model is the default
+	approve ApprovalFunc
+	logger  *slog.Logger
+}
+
+var _ client.SamplingHandler = (*Handler)(nil)
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithModelName sets the name of the default model, which is reported to servers
+// and matched against model preference hints.
+func WithModelName(name string) Option {
+	return func(h *Handler) {
+		h.models[0].name = name
+	}
+}
+
+// WithModel registers an additional model that is used when a model preference hint
+// from the server matches its name.
+func WithModel(name string, model llms.Model) Option {
+	return func(h *Handler) {
+		h.models = append(h.models, namedModel{name: name, model: model})
+	}
+}
+
+// WithApproval sets a hook that must approve every sampling request before it is executed.
+// Without it, all requests are executed.
+func WithApproval(fn ApprovalFunc) Option {
+	return func(h *Handler) {
+		h.approve = fn
+	}
+}
+
+// WithLogger sets the logger of the handler. Defaults to slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(h *Handler) {
+		h.logger = logger
+	}
+}
+
+// NewHandler creates a sampling handler backed by model.
+func NewHandler(model llms.Model, opts ...Option) *Handler {
+	h := &Handler{
+		models: []namedModel{{name: DefaultModelName, model: model}},
+		logger: slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// CreateMessage implements client.SamplingHandler.
+func (h *Handler) CreateMessage(ctx context.Context, request mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
+	if h.approve != nil {
+		approved, err := h.approve(ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("sampling approval failed: %w", err)
+		}
+		if !approved {
+			h.logger.Debug("Sampling request rejected by approval hook")
+			return nil, ErrRejected
+		}
+	}
+
+	messages, err := ConvertSamplingMessages(request.SystemPrompt, request.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := h.selectModel(request.ModelPreferences)
+	h.logger.Debug("Sampling request using model", "model", selected.name, "message_count", len(messages))
+
+	response, err := selected.model.GenerateContent(ctx, messages, callOptions(ctx, request.CreateMessageParams)...)
+	if err != nil {
+		return nil, fmt.Errorf("sampling model %s failed: %w", selected.name, err)
+	}
+	if len(response.Choices) == 0 {
+		return nil, fmt.Errorf("sampling model %s returned no choices", selected.name)
+	}
+	choice := response.Choices[0]
+
+	return &mcp.CreateMessageResult{
+		SamplingMessage: mcp.SamplingMessage{
+			Role:    mcp.RoleAssistant,
+			Content: mcp.NewTextContent(choice.Content),
+		},
+		Model:      selected.name,
+		StopReason: convertStopReason(choice.StopReason),
+	}, nil
+}
+
+// selectModel returns the first model whose name contains a hint, evaluating hints in order.
+// It falls back to the default model.
+func (h *Handler) selectModel(preferences *mcp.ModelPreferences) namedModel {
+	if preferences != nil {
+		for _, hint := range preferences.Hints {
+			if hint.Name == "" {
+				continue
+			}
+			for _, candidate := range h.models {
+				if strings.Contains(candidate.name, hint.Name) {
+					return candidate
+				}
+			}
+		}
+	}
+	return h.models[0]
+}
+
+type requestParamsKey struct{}
+
+// ContextWithRequestParams returns a context carrying the raw params of a sampling request.
+// mcp-go decodes a missing temperature as 0, so without them a Handler only forwards
+// temperatures above 0. MultiServerMCPClient adds them to the context of server requests.
+func ContextWithRequestParams(ctx context.Context, params any) context.Context {
+	return context.WithValue(ctx, requestParamsKey{}, params)
+}
+
+// requestTemperature returns the temperature of the raw params in ctx, and whether they
+// set one. ok is false as well if the context carries no params.
+func requestTemperature(ctx context.Context) (temperature float64, ok bool) {
+	params := ctx.Value(requestParamsKey{})
+	if params == nil {
+		return 0, false
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		return 0, false
+	}
+	var decoded struct {
+		Temperature *float64 `json:"temperature"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Temperature == nil {
+		return 0, false
+	}
+	return *decoded.Temperature, true
+}
+
+// callOptions converts the sampling parameters to langchaingo call options.
+func callOptions(ctx context.Context, params mcp.CreateMessageParams) []llms.CallOption {
+	var opts []llms.CallOption
+	if params.MaxTokens > 0 {
+		opts = append(opts, llms.WithMaxTokens(params.MaxTokens))
+	}
+	// An explicit temperature of 0 asks for deterministic sampling, rather than the default
+	if temperature, ok := requestTemperature(ctx); ok {
+		opts = append(opts, llms.WithTemperature(temperature))
+	} else if params.Temperature > 0 {
+		opts = append(opts, llms.WithTemperature(params.Temperature))
+	}
+	if len(params.StopSequences) > 0 {
+		opts = append(opts, llms.WithStopWords(params.StopSequences))
+	}
+	return opts
+}
+
+// ConvertSamplingMessages converts MCP sampling messages to langchaingo message contents.
+// A non-empty systemPrompt is prepended as a system message.
+func ConvertSamplingMessages(systemPrompt string, messages []mcp.SamplingMessage) ([]llms.MessageContent, error) {
+	result := make([]llms.MessageContent, 0, len(messages)+1)
+	if systemPrompt != "" {
+		result = append(result, llms.TextParts(llms.ChatMessageTypeSystem, systemPrompt))
+	}
+	for i, message := range messages {
+		converted, err := convertSamplingMessage(message)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert sampling message %d: %w", i, err)
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
+
+// convertSamplingMessage converts a single MCP sampling message to a langchaingo message content.
+func convertSamplingMessage(message mcp.SamplingMessage) (llms.MessageContent, error) {
+	var role llms.ChatMessageType
+	switch message.Role {
+	case mcp.RoleUser:
+		role = llms.ChatMessageTypeHuman
+	case mcp.RoleAssistant:
+		role = llms.ChatMessageTypeAI
+	default:
+		return llms.MessageContent{}, fmt.Errorf("unsupported sampling message role: %s", message.Role)
+	}
+
+	content := message.Content
+	if contentMap, ok := content.(map[string]any); ok {
+		parsed, err := mcp.ParseContent(contentMap)
+		if err != nil {
+			return llms.MessageContent{}, err
+		}
+		content = parsed
+	}
+
+	var part llms.ContentPart
+	switch c := content.(type) {
+	case mcp.TextContent:
+		part = llms.TextPart(c.Text)
+	case *mcp.TextContent:
+		part = llms.TextPart(c.Text)
+	case mcp.ImageContent:
+		data, err := base64.StdEncoding.DecodeString(c.Data)
+		if err != nil {
+			return llms.MessageContent{}, fmt.Errorf("invalid image data: %w", err)
+		}
+		part = llms.BinaryPart(c.MIMEType, data)
+	case *mcp.ImageContent:
+		data, err := base64.StdEncoding.DecodeString(c.Data)
+		if err != nil {
+			return llms.MessageContent{}, fmt.Errorf("invalid image data: %w", err)
+		}
+		part = llms.BinaryPart(c.MIMEType, data)
+	default:
+		return llms.MessageContent{}, fmt.Errorf("unsupported sampling message content type: %T", message.Content)
+	}
+
+	return llms.MessageContent{Role: role, Parts: []llms.ContentPart{part}}, nil
+}
+
+// convertStopReason maps provider specific stop reasons to the values defined by MCP.
+// Unknown reasons are passed through unchanged.
+func convertStopReason(reason string) string {
+	switch strings.ToLower(reason) {
+	case "stop", "end_turn", "endturn":
+		return "endTurn"
+	case "length", "max_tokens", "maxtokens":
+		return "maxTokens"
+	case "stop_sequence", "stopsequence":
+		return "stopSequence"
+	default:
+		return reason
+	}
+}
@@ -0,0 +1,232 @@
+package sampling
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// --- Fakes ---
+
+type FakeModel struct {
+	response *llms.ContentResponse
+	err      error
+
+	messages []llms.MessageContent
+	options  llms.CallOptions
+	calls    int
+}
+
+func (m *FakeModel) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	m.calls++
+	m.messages = messages
+	for _, opt := range options {
+		opt(&m.options)
+	}
+	return m.response, m.err
+}
+
+func (m *FakeModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func textResponse(text, stopReason string) *llms.ContentResponse {
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: text, StopReason: stopReason}}}
+}
+
+func createMessageRequest(params mcp.CreateMessageParams) mcp.CreateMessageRequest {
+	request := mcp.CreateMessageRequest{}
+	request.CreateMessageParams = params
+	return request
+}
+
+// --- Tests ---
+
+func TestConvertSamplingMessages(t *testing.T) {
+	imageData := []byte{0x89, 0x50, 0x4e, 0x47}
+	messages := []mcp.SamplingMessage{
+		{Role: mcp.RoleUser, Content: mcp.NewTextContent("What is in this image?")},
+		{Role: mcp.RoleUser, Content: mcp.NewImageContent(base64.StdEncoding.EncodeToString(imageData), "image/png")},
+		{Role: mcp.RoleAssistant, Content: map[string]any{"type": "text", "text": "A logo."}},
+	}
+
+	converted, err := ConvertSamplingMessages("You are helpful.", messages)
+
+	require.NoError(t, err)
+	assert.Equal(t, []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, "You are helpful."),
+		llms.TextParts(llms.ChatMessageTypeHuman, "What is in this image?"),
+		{Role: llms.ChatMessageTypeHuman, Parts: []llms.ContentPart{llms.BinaryPart("image/png", imageData)}},
+		llms.TextParts(llms.ChatMessageTypeAI, "A logo."),
+	}, converted)
+}
+
+func TestConvertSamplingMessages_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		message mcp.SamplingMessage
+	}{
+		{name: "Unsupported role", message: mcp.SamplingMessage{Role: "system", Content: mcp.NewTextContent("x")}},
+		{name: "Unsupported content", message: mcp.SamplingMessage{Role: mcp.RoleUser, Content: mcp.NewAudioContent("AAAA", "audio/wav")}},
+		{name: "Invalid image data", message: mcp.SamplingMessage{Role: mcp.RoleUser, Content: mcp.NewImageContent("not base64!", "image/png")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ConvertSamplingMessages("", []mcp.SamplingMessage{tt.message})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestHandler_CreateMessage(t *testing.T) {
+	model := &FakeModel{response: textResponse("Paris", "end_turn")}
+	handler := NewHandler(model, WithModelName("claude-3-5-sonnet"))
+
+	result, err := handler.CreateMessage(context.Background(), createMessageRequest(mcp.CreateMessageParams{
+		Messages:      []mcp.SamplingMessage{{Role: mcp.RoleUser, Content: mcp.NewTextContent("Capital of France?")}},
+		SystemPrompt:  "Answer briefly.",
+		MaxTokens:     64,
+		Temperature:   0.2,
+		StopSequences: []string{"\n\n"},
+	}))
+
+	require.NoError(t, err)
+	assert.Equal(t, mcp.RoleAssistant, result.Role)
+	assert.Equal(t, mcp.NewTextContent("Paris"), result.Content)
+	assert.Equal(t, "claude-3-5-sonnet", result.Model)
+	assert.Equal(t, "endTurn", result.StopReason)
+
+	require.Len(t, model.messages, 2)
+	assert.Equal(t, llms.ChatMessageTypeSystem, model.messages[0].Role)
+	assert.Equal(t, 64, model.options.MaxTokens)
+	assert.Equal(t, 0.2, model.options.Temperature)
+	assert.Equal(t, []string{"\n\n"}, model.options.StopWords)
+}
+
+func TestHandler_CreateMessage_Temperature(t *testing.T) {
+	request := createMessageRequest(mcp.CreateMessageParams{
+		Messages: []mcp.SamplingMessage{{Role: mcp.RoleUser, Content: mcp.NewTextContent("hi")}},
+	})
+	tests := []struct {
+		name     string
+		params   any
+		expected float64
+	}{
+		{"explicit zero", map[string]any{"temperature": 0}, 0},
+		{"missing", map[string]any{"maxTokens": 64}, 0.7},
+		{"no raw params", nil, 0.7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The temperature of the model is kept unless the request sets one
+			model := &FakeModel{response: textResponse("hi", "stop"), options: llms.CallOptions{Temperature: 0.7}}
+			ctx := context.Background()
+			if tt.params != nil {
+				ctx = ContextWithRequestParams(ctx, tt.params)
+			}
+
+			_, err := NewHandler(model).CreateMessage(ctx, request)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, model.options.Temperature)
+		})
+	}
+}
+
+func TestHandler_CreateMessage_ModelPreferences(t *testing.T) {
+	defaultModel := &FakeModel{response: textResponse("default", "stop")}
+	fastModel := &FakeModel{response: textResponse("fast", "stop")}
+	handler := NewHandler(defaultModel, WithModelName("gpt-4o"), WithModel("claude-3-haiku", fastModel))
+
+	request := createMessageRequest(mcp.CreateMessageParams{
+		Messages: []mcp.SamplingMessage{{Role: mcp.RoleUser, Content: mcp.NewTextContent("hi")}},
+		ModelPreferences: &mcp.ModelPreferences{
+			Hints: []mcp.ModelHint{{Name: "gemini"}, {Name: "haiku"}, {Name: "gpt"}},
+		},
+	})
+
+	result, err := handler.CreateMessage(context.Background(), request)
+
+	require.NoError(t, err)
+	assert.Equal(t, "claude-3-haiku", result.Model)
+	assert.Equal(t, 1, fastModel.calls)
+	assert.Equal(t, 0, defaultModel.calls)
+
+	request.ModelPreferences.Hints = []mcp.ModelHint{{Name: "unknown"}}
+	result, err = handler.CreateMessage(context.Background(), request)
+
+	require.NoError(t, err)
+	assert.Equal(t, "gpt-4o", result.Model, "Unmatched hints fall back to the default model")
+}
+
+func TestHandler_CreateMessage_Approval(t *testing.T) {
+	model := &FakeModel{response: textResponse("ok", "stop")}
+	request := createMessageRequest(mcp.CreateMessageParams{
+		Messages: []mcp.SamplingMessage{{Role: mcp.RoleUser, Content: mcp.NewTextContent("hi")}},
+	})
+
+	rejecting := NewHandler(model, WithApproval(func(ctx context.Context, r mcp.CreateMessageRequest) (bool, error) {
+		return false, nil
+	}))
+	_, err := rejecting.CreateMessage(context.Background(), request)
+	assert.ErrorIs(t, err, ErrRejected)
+
+	failing := NewHandler(model, WithApproval(func(ctx context.Context, r mcp.CreateMessageRequest) (bool, error) {
+		return false, errors.New("prompt closed")
+	}))
+	_, err = failing.CreateMessage(context.Background(), request)
+	assert.ErrorContains(t, err, "prompt closed")
+
+	assert.Equal(t, 0, model.calls, "The model must not be called without approval")
+
+	approving := NewHandler(model, WithApproval(func(ctx context.Context, r mcp.CreateMessageRequest) (bool, error) {
+		return true, nil
+	}))
+	_, err = approving.CreateMessage(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, 1, model.calls)
+}
+
+func TestHandler_CreateMessage_WithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	handler := NewHandler(&FakeModel{response: textResponse("ok", "stop")}, WithLogger(logger))
+
+	_, err := handler.CreateMessage(context.Background(), createMessageRequest(mcp.CreateMessageParams{
+		Messages: []mcp.SamplingMessage{{Role: mcp.RoleUser, Content: mcp.NewTextContent("hi")}},
+	}))
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Sampling request using model")
+}
+
+func TestHandler_CreateMessage_ModelErrors(t *testing.T) {
+	request := createMessageRequest(mcp.CreateMessageParams{
+		Messages: []mcp.SamplingMessage{{Role: mcp.RoleUser, Content: mcp.NewTextContent("hi")}},
+	})
+
+	_, err := NewHandler(&FakeModel{err: errors.New("rate limited")}).CreateMessage(context.Background(), request)
+	assert.ErrorContains(t, err, "rate limited")
+
+	_, err = NewHandler(&FakeModel{response: &llms.ContentResponse{}}).CreateMessage(context.Background(), request)
+	assert.ErrorContains(t, err, "no choices")
+}
+
+func TestConvertStopReason(t *testing.T) {
+	assert.Equal(t, "endTurn", convertStopReason("stop"))
+	assert.Equal(t, "endTurn", convertStopReason("end_turn"))
+	assert.Equal(t, "maxTokens", convertStopReason("length"))
+	assert.Equal(t, "maxTokens", convertStopReason("MAX_TOKENS"))
+	assert.Equal(t, "stopSequence", convertStopReason("stop_sequence"))
+	assert.Equal(t, "tool_calls", convertStopReason("tool_calls"))
+}
@@ -0,0 +1,199 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/memory"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// ChainOption configures a chain tool created by NewChainTool.
+type ChainOption func(*chainOptions)
+
+type chainOptions struct {
+	structured  bool
+	callOptions []chains.ChainCallOption
+	callbacks   callbacks.Handler
+	logger      *slog.Logger
+}
+
+// WithStructuredOutput always returns the output values of the chain as structured content,
+// even when the chain has a single string output.
+func WithStructuredOutput() ChainOption {
+	return func(o *chainOptions) {
+		o.structured = true
+	}
+}
+
+// WithChainCallOptions sets options passed to chains.Call on every tool call.
+func WithChainCallOptions(options ...chains.ChainCallOption) ChainOption {
+	return func(o *chainOptions) {
+		o.callOptions = append(o.callOptions, options...)
+	}
+}
+
+// WithChainCallbacksHandler sends the chain start, end and error events of every tool call to
+// handler, together with the ProgressHandler of the call and the handler of the chain itself.
+func WithChainCallbacksHandler(handler callbacks.Handler) ChainOption {
+	return func(o *chainOptions) {
+		o.callbacks = handler
+	}
+}
+
+// WithChainLogger sets the logger of the chain tool. Defaults to slog.Default().
+func WithChainLogger(logger *slog.Logger) ChainOption {
+	return func(o *chainOptions) {
+		o.logger = logger
+	}
+}
+
+// NewChainTool converts a LangchainGo chain into an MCP tool and its handler. Agents can be
+// exposed the same way through their agents.Executor.
+//
+// The input keys of the chain that are not provided by its memory become required string
+// arguments. A chain with a single output key returns its value as text; otherwise the output
+// values are returned as structured content described by the output schema of the tool.
+// Calls report progress when the client sends a progress token: the start and end of the chain
+// are always reported, the steps inside it only by the LLMs, agents and retrievers that have a
+// ProgressHandler, see ProgressHandler.
+//
+// A call returns as soon as its request is cancelled. The chain receives the context of the
+// request, but may run on until it notices the cancellation.
+func NewChainTool(name, description string, chain chains.Chain, opts ...ChainOption) server.ServerTool {
+	options := &chainOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.logger == nil {
+		options.logger = slog.Default()
+	}
+	logger := options.logger.With("tool_name", name)
+
+	chain = callbacksChain{Chain: chain, handler: options.callbacks}
+	memoryKeys := chain.GetMemory().MemoryVariables(context.Background())
+	toolOptions := []mcp.ToolOption{mcp.WithDescription(description)}
+	var inputKeys []string
+	for _, key := range chain.GetInputKeys() {
+		if slices.Contains(memoryKeys, key) {
+			continue
+		}
+		inputKeys = append(inputKeys, key)
+		toolOptions = append(toolOptions, mcp.WithString(key, mcp.Required()))
+	}
+
+	outputKeys := chain.GetOutputKeys()
+	structured := options.structured || len(outputKeys) != 1
+	mcpTool := mcp.NewTool(name, toolOptions...)
+	if structured {
+		properties := make(map[string]any, len(outputKeys))
+		for _, key := range outputKeys {
+			properties[key] = map[string]any{}
+		}
+		mcpTool.OutputSchema = mcp.ToolOutputSchema{Type: "object", Properties: properties, Required: outputKeys}
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments := request.GetArguments()
+		inputs := make(map[string]any, len(inputKeys))
+		for _, key := range inputKeys {
+			value, ok := arguments[key]
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("missing required argument %q", key)), nil
+			}
+			inputs[key] = value
+		}
+
+		reporter := newProgressReporter(ctx, request, logger)
+		ctx = contextWithProgressReporter(ctx, reporter)
+		reporter.report(ctx, fmt.Sprintf("running %s", name))
+
+		type chainResult struct {
+			outputs map[string]any
+			err     error
+		}
+		done := make(chan chainResult, 1)
+		go func() {
+			// The chain sees the cancellation of ctx, but is not waited for once it happens
+			outputs, err := chains.Call(ctx, chain, inputs, options.callOptions...)
+			done <- chainResult{outputs: outputs, err: err}
+		}()
+
+		logger.Debug("ChainTool calling LangchainGo chain")
+		var result chainResult
+		select {
+		case <-ctx.Done():
+			logger.Debug("ChainTool call cancelled", "error", ctx.Err())
+			return mcp.NewToolResultError(fmt.Sprintf("chain call cancelled: %v", ctx.Err())), nil
+		case result = <-done:
+		}
+		if result.err != nil {
+			logger.Debug("ChainTool LangchainGo chain returned error", "error", result.err)
+			return mcp.NewToolResultError(result.err.Error()), nil
+		}
+
+		return chainToolResult(result.outputs, outputKeys, structured)
+	}
+
+	return server.ServerTool{Tool: mcpTool, Handler: handler}
+}
+
+// callbacksChain reports the chain events of a chain to a ProgressHandler and an optional
+// handler, in addition to the handler of the chain. chains.Call only sends these events to the
+// handler of the chain it calls, so they cannot be passed as call options.
+type callbacksChain struct {
+	chains.Chain
+	handler callbacks.Handler
+}
+
+var _ callbacks.HandlerHaver = callbacksChain{}
+
+func (c callbacksChain) GetCallbackHandler() callbacks.Handler {
+	handlers := []callbacks.Handler{ProgressHandler{}}
+	if haver, ok := c.Chain.(callbacks.HandlerHaver); ok && haver.GetCallbackHandler() != nil {
+		handlers = append(handlers, haver.GetCallbackHandler())
+	}
+	if c.handler != nil {
+		handlers = append(handlers, c.handler)
+	}
+	return callbacks.CombiningHandler{Callbacks: handlers}
+}
+
+// GetMemory returns the memory of the chain, or an empty memory for chains without one.
+func (c callbacksChain) GetMemory() schema.Memory {
+	if chainMemory := c.Chain.GetMemory(); chainMemory != nil {
+		return chainMemory
+	}
+	return memory.NewSimple()
+}
+
+// chainToolResult converts the output values of a chain into a tool result.
+func chainToolResult(outputs map[string]any, outputKeys []string, structured bool) (*mcp.CallToolResult, error) {
+	if !structured {
+		if text, ok := outputs[outputKeys[0]].(string); ok {
+			return mcp.NewToolResultText(text), nil
+		}
+	}
+
+	selected := make(map[string]any, len(outputKeys))
+	for _, key := range outputKeys {
+		selected[key] = outputs[key]
+	}
+	fallback, err := json.Marshal(selected)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode chain outputs: %w", err)
+	}
+	return mcp.NewToolResultStructured(selected, string(fallback)), nil
+}
+
+// AddChain registers a LangchainGo chain as a tool on an MCP server.
+func AddChain(s *server.MCPServer, name, description string, chain chains.Chain, opts ...ChainOption) {
+	s.AddTools(NewChainTool(name, description, chain, opts...))
+}
@@ -0,0 +1,273 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/memory"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+
+	lcgomcptool "github.com/akihiro-fukuchi/langchaingo-mcp-adapters/tool"
+)
+
+// --- Fakes ---
+
+type FakeChain struct {
+	inputKeys  []string
+	outputKeys []string
+	outputs    map[string]any
+	err        error
+	memory     schema.Memory
+	block      bool
+
+	inputs map[string]any
+}
+
+func (f *FakeChain) Call(ctx context.Context, inputs map[string]any, options ...chains.ChainCallOption) (map[string]any, error) {
+	f.inputs = inputs
+	if f.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return f.outputs, f.err
+}
+
+func (f *FakeChain) GetMemory() schema.Memory { return f.memory }
+
+func (f *FakeChain) GetInputKeys() []string  { return f.inputKeys }
+func (f *FakeChain) GetOutputKeys() []string { return f.outputKeys }
+
+// FakeLLM answers every prompt with the same text and reports its calls to its callbacks
+// handler, like the LLMs of langchaingo.
+type FakeLLM struct {
+	CallbacksHandler callbacks.Handler
+	response         string
+}
+
+func (l *FakeLLM) GenerateContent(ctx context.Context, messages []llms.MessageContent, _ ...llms.CallOption) (*llms.ContentResponse, error) {
+	if l.CallbacksHandler != nil {
+		l.CallbacksHandler.HandleLLMGenerateContentStart(ctx, messages)
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: l.response}}}, nil
+}
+
+func (l *FakeLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, l, prompt, options...)
+}
+
+// FakeChainHandler records the chain events it receives.
+type FakeChainHandler struct {
+	callbacks.SimpleHandler
+	events []string
+}
+
+func (h *FakeChainHandler) HandleChainStart(context.Context, map[string]any) {
+	h.events = append(h.events, "start")
+}
+
+func (h *FakeChainHandler) HandleChainEnd(context.Context, map[string]any) {
+	h.events = append(h.events, "end")
+}
+
+type FakeMemory struct {
+	memory.Simple
+	variables []string
+}
+
+func (m FakeMemory) MemoryVariables(context.Context) []string { return m.variables }
+
+func (m FakeMemory) LoadMemoryVariables(context.Context, map[string]any) (map[string]any, error) {
+	values := map[string]any{}
+	for _, variable := range m.variables {
+		values[variable] = "remembered"
+	}
+	return values, nil
+}
+
+// FakeSession is an initialized client session that collects notifications.
+type FakeSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *FakeSession) Initialize()       {}
+func (s *FakeSession) Initialized() bool { return true }
+func (s *FakeSession) SessionID() string { return "fake-session" }
+func (s *FakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+// --- Tests ---
+
+func TestNewChainTool_TextOutput(t *testing.T) {
+	chain := &FakeChain{
+		inputKeys:  []string{"question", "history"},
+		outputKeys: []string{"text"},
+		outputs:    map[string]any{"text": "42"},
+		memory:     FakeMemory{variables: []string{"history"}},
+	}
+	s := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(false))
+	AddChain(s, "qa", "Answers questions", chain)
+	mcpClient := newInProcessClient(t, s)
+
+	listResult, err := mcpClient.ListTools(context.Background(), mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	require.Len(t, listResult.Tools, 1)
+	assert.Equal(t, "Answers questions", listResult.Tools[0].Description)
+	assert.Equal(t, []string{"question"}, listResult.Tools[0].InputSchema.Required, "Memory variables are not arguments")
+	assert.Empty(t, listResult.Tools[0].OutputSchema.Properties)
+
+	result := callTool(t, mcpClient, "qa", map[string]any{"question": "meaning of life?"})
+
+	assert.False(t, result.IsError)
+	assert.Equal(t, []mcp.Content{mcp.NewTextContent("42")}, result.Content)
+	assert.Equal(t, map[string]any{"question": "meaning of life?", "history": "remembered"}, chain.inputs)
+}
+
+func TestNewChainTool_StructuredOutput(t *testing.T) {
+	chain := &FakeChain{
+		inputKeys:  []string{"query"},
+		outputKeys: []string{"answer", "sources"},
+		outputs:    map[string]any{"answer": "Paris", "sources": []string{"wiki"}, "ignored": true},
+	}
+	s := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(false))
+	AddChain(s, "search", "Searches", chain)
+	mcpClient := newInProcessClient(t, s)
+
+	listResult, err := mcpClient.ListTools(context.Background(), mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	require.Len(t, listResult.Tools, 1)
+	assert.Equal(t, []string{"answer", "sources"}, listResult.Tools[0].OutputSchema.Required)
+
+	result := callTool(t, mcpClient, "search", map[string]any{"query": "capital of France"})
+
+	assert.False(t, result.IsError)
+	assert.Equal(t, map[string]any{"answer": "Paris", "sources": []any{"wiki"}}, result.StructuredContent)
+	require.Len(t, result.Content, 1)
+	var fallback map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &fallback))
+	assert.Equal(t, result.StructuredContent, fallback)
+}
+
+func TestNewChainTool_WithStructuredOutput(t *testing.T) {
+	chain := &FakeChain{inputKeys: []string{"input"}, outputKeys: []string{"output"}, outputs: map[string]any{"output": "done"}}
+	serverTool := NewChainTool("agent", "Runs the agent", chain, WithStructuredOutput())
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"input": "go"}
+	result, err := serverTool.Handler(context.Background(), request)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"output": "done"}, result.StructuredContent)
+	assert.Equal(t, []string{"output"}, serverTool.Tool.OutputSchema.Required)
+}
+
+func TestNewChainTool_Errors(t *testing.T) {
+	chain := &FakeChain{inputKeys: []string{"input"}, outputKeys: []string{"output"}, err: errors.New("llm unavailable")}
+	s := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(false))
+	AddChain(s, "agent", "Runs the agent", chain)
+	mcpClient := newInProcessClient(t, s)
+
+	result := callTool(t, mcpClient, "agent", map[string]any{})
+	assert.True(t, result.IsError)
+	assert.Equal(t, []mcp.Content{mcp.NewTextContent(`missing required argument "input"`)}, result.Content)
+
+	result = callTool(t, mcpClient, "agent", map[string]any{"input": "go"})
+	assert.True(t, result.IsError)
+	assert.Equal(t, []mcp.Content{mcp.NewTextContent("llm unavailable")}, result.Content)
+}
+
+func TestNewChainTool_Cancellation(t *testing.T) {
+	chain := &FakeChain{inputKeys: []string{"input"}, outputKeys: []string{"output"}, block: true}
+	serverTool := NewChainTool("agent", "Runs the agent", chain)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"input": "go"}
+	result, err := serverTool.Handler(ctx, request)
+
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "cancelled")
+}
+
+// callChainToolWithProgress calls the tool name of s with a progress token and returns the
+// messages of the progress notifications sent during the call.
+func callChainToolWithProgress(t *testing.T, s *server.MCPServer, name string) []string {
+	session := &FakeSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	require.NoError(t, s.RegisterSession(context.Background(), session))
+
+	message := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"` + name + `","arguments":{"input":"go"},"_meta":{"progressToken":"call-1"}}}`
+	response := s.HandleMessage(s.WithContext(context.Background(), session), []byte(message))
+	require.IsType(t, mcp.JSONRPCResponse{}, response)
+	require.False(t, response.(mcp.JSONRPCResponse).Result.(mcp.CallToolResult).IsError)
+
+	close(session.notifications)
+	var messages []string
+	var progress []float64
+	for notification := range session.notifications {
+		assert.Equal(t, lcgomcptool.MethodNotificationProgress, notification.Method)
+		assert.Equal(t, "call-1", notification.Params.AdditionalFields["progressToken"])
+		messages = append(messages, notification.Params.AdditionalFields["message"].(string))
+		progress = append(progress, notification.Params.AdditionalFields["progress"].(float64))
+	}
+	for i, p := range progress {
+		assert.Equal(t, float64(i+1), p)
+	}
+	return messages
+}
+
+func TestNewChainTool_Progress(t *testing.T) {
+	llm := &FakeLLM{CallbacksHandler: ProgressHandler{}, response: "done"}
+	chain := chains.NewLLMChain(llm, prompts.NewPromptTemplate("Do {{.input}}", []string{"input"}))
+	s := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(false))
+	AddChain(s, "agent", "Runs the agent", chain)
+
+	messages := callChainToolWithProgress(t, s, "agent")
+
+	assert.Equal(t, []string{"running agent", "chain started", "generating content", "chain finished"}, messages)
+}
+
+func TestNewChainTool_CallbacksHandler(t *testing.T) {
+	chainHandler := &FakeChainHandler{}
+	handler := &FakeChainHandler{}
+	chain := chains.NewLLMChain(&FakeLLM{response: "done"}, prompts.NewPromptTemplate("Do {{.input}}", []string{"input"}),
+		chains.WithCallback(chainHandler))
+	s := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(false))
+	AddChain(s, "agent", "Runs the agent", chain, WithChainCallbacksHandler(handler), WithChainCallOptions(chains.WithMaxTokens(10)))
+
+	messages := callChainToolWithProgress(t, s, "agent")
+
+	assert.Equal(t, []string{"running agent", "chain started", "chain finished"}, messages, "Progress should still be reported")
+	assert.Equal(t, []string{"start", "end"}, handler.events)
+	assert.Equal(t, []string{"start", "end"}, chainHandler.events, "The handler of the chain should still receive its events")
+}
+
+func TestNewChainTool_NilMemory(t *testing.T) {
+	chain := &FakeChain{inputKeys: []string{"input"}, outputKeys: []string{"output"}, outputs: map[string]any{"output": "done"}}
+	serverTool := NewChainTool("agent", "Runs the agent", chain)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"input": "go"}
+	result, err := serverTool.Handler(context.Background(), request)
+
+	require.NoError(t, err)
+	assert.Equal(t, []mcp.Content{mcp.NewTextContent("done")}, result.Content)
+}
+
+func TestProgressHandler_WithoutReporter(t *testing.T) {
+	assert.NotPanics(t, func() {
+		ProgressHandler{}.HandleChainStart(context.Background(), nil)
+		ProgressHandler{}.HandleAgentAction(context.Background(), schema.AgentAction{Tool: "search"})
+	})
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+
+	lcgomcptool "github.com/akihiro-fukuchi/langchaingo-mcp-adapters/tool"
+)
+
+// progressReporter sends notifications/progress for a single tools/call request.
+type progressReporter struct {
+	srv    *server.MCPServer
+	token  mcp.ProgressToken
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	progress float64
+}
+
+type progressReporterKey struct{}
+
+// newProgressReporter returns a reporter for request, or nil when the client did not ask
+// for progress notifications.
+func newProgressReporter(ctx context.Context, request mcp.CallToolRequest, logger *slog.Logger) *progressReporter {
+	srv := server.ServerFromContext(ctx)
+	if srv == nil || request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return nil
+	}
+	return &progressReporter{srv: srv, token: request.Params.Meta.ProgressToken, logger: logger}
+}
+
+// report advances the progress by one step and sends message to the client.
+// It is a no-op on a nil reporter.
+func (r *progressReporter) report(ctx context.Context, message string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.progress++
+	params := map[string]any{
+		"progressToken": r.token,
+		"progress":      r.progress,
+		"message":       message,
+	}
+	if err := r.srv.SendNotificationToClient(ctx, lcgomcptool.MethodNotificationProgress, params); err != nil {
+		r.logger.Debug("progressReporter failed to send progress notification", "error", err)
+	}
+}
+
+func contextWithProgressReporter(ctx context.Context, r *progressReporter) context.Context {
+	if r == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, progressReporterKey{}, r)
+}
+
+func progressReporterFromContext(ctx context.Context) *progressReporter {
+	r, _ := ctx.Value(progressReporterKey{}).(*progressReporter)
+	return r
+}
+
+// ProgressHandler is a callbacks.Handler that turns intermediate chain, LLM, tool and
+// retriever events into MCP progress notifications for the tool call being served.
+// Events outside of a tool call, or of calls without a progress token, are ignored.
+//
+// Chain tools report the start and end of their chain with it. To report the steps inside the
+// chain as well, set it as the callbacks handler of the LLMs, agent executors and retrievers
+// used by the chain, e.g. with openai.WithCallback or agents.WithCallbacksHandler.
+type ProgressHandler struct {
+	callbacks.SimpleHandler
+}
+
+var _ callbacks.Handler = ProgressHandler{}
+
+func (ProgressHandler) HandleChainStart(ctx context.Context, _ map[string]any) {
+	progressReporterFromContext(ctx).report(ctx, "chain started")
+}
+
+func (ProgressHandler) HandleChainEnd(ctx context.Context, _ map[string]any) {
+	progressReporterFromContext(ctx).report(ctx, "chain finished")
+}
+
+func (ProgressHandler) HandleLLMGenerateContentStart(ctx context.Context, _ []llms.MessageContent) {
+	progressReporterFromContext(ctx).report(ctx, "generating content")
+}
+
+func (ProgressHandler) HandleToolStart(ctx context.Context, input string) {
+	progressReporterFromContext(ctx).report(ctx, "calling tool")
+}
+
+func (ProgressHandler) HandleAgentAction(ctx context.Context, action schema.AgentAction) {
+	progressReporterFromContext(ctx).report(ctx, fmt.Sprintf("agent action: %s", action.Tool))
+}
+
+func (ProgressHandler) HandleRetrieverStart(ctx context.Context, query string) {
+	progressReporterFromContext(ctx).report(ctx, "retrieving documents")
+}
+
+func (ProgressHandler) HandleRetrieverEnd(ctx context.Context, _ string, documents []schema.Document) {
+	progressReporterFromContext(ctx).report(ctx, fmt.Sprintf("retrieved %d documents", len(documents)))
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+)
+
+// PromptOption configures a prompt created by NewServerPrompt.
+type PromptOption func(*promptOptions)
+
+type promptOptions struct {
+	description          string
+	argumentDescriptions map[string]string
+	optionalArguments    []string
+	logger               *slog.Logger
+}
+
+// WithPromptDescription sets the description of the prompt.
+func WithPromptDescription(description string) PromptOption {
+	return func(o *promptOptions) {
+		o.description = description
+	}
+}
+
+// WithArgumentDescriptions sets the descriptions of prompt arguments by input variable name.
+func WithArgumentDescriptions(descriptions map[string]string) PromptOption {
+	return func(o *promptOptions) {
+		o.argumentDescriptions = descriptions
+	}
+}
+
+// WithOptionalArguments marks input variables as optional arguments, which are formatted as
+// empty strings when a client leaves them out. Variables with a partial value in a
+// prompts.ChatPromptTemplate are always optional.
+func WithOptionalArguments(names ...string) PromptOption {
+	return func(o *promptOptions) {
+		o.optionalArguments = append(o.optionalArguments, names...)
+	}
+}
+
+// WithPromptLogger sets the logger of the prompt. Defaults to slog.Default().
+func WithPromptLogger(logger *slog.Logger) PromptOption {
+	return func(o *promptOptions) {
+		o.logger = logger
+	}
+}
+
+// NewServerPrompt converts a LangchainGo prompt template, such as a prompts.ChatPromptTemplate,
+// into an MCP prompt and its handler. Every input variable of the template becomes an argument.
+//
+// prompts/get renders the template into prompt messages. Human and generic messages get the
+// user role and AI messages the assistant role. MCP prompts have no system role, so system
+// messages are sent with the user role as well.
+func NewServerPrompt(name string, template prompts.MessageFormatter, opts ...PromptOption) server.ServerPrompt {
+	options := &promptOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.logger == nil {
+		options.logger = slog.Default()
+	}
+
+	optional := slices.Clone(options.optionalArguments)
+	var partialVariables map[string]any
+	if chatTemplate, ok := template.(prompts.ChatPromptTemplate); ok {
+		partialVariables = chatTemplate.PartialVariables
+		for variable := range partialVariables {
+			optional = append(optional, variable)
+		}
+	}
+
+	variables := slices.Clone(template.GetInputVariables())
+	slices.Sort(variables)
+	variables = slices.Compact(variables)
+
+	promptOptions := []mcp.PromptOption{mcp.WithPromptDescription(options.description)}
+	for _, variable := range variables {
+		var argumentOptions []mcp.ArgumentOption
+		if description, ok := options.argumentDescriptions[variable]; ok {
+			argumentOptions = append(argumentOptions, mcp.ArgumentDescription(description))
+		}
+		if !slices.Contains(optional, variable) {
+			argumentOptions = append(argumentOptions, mcp.RequiredArgument())
+		}
+		promptOptions = append(promptOptions, mcp.WithArgument(variable, argumentOptions...))
+	}
+	mcpPrompt := mcp.NewPrompt(name, promptOptions...)
+
+	handler := func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		values := make(map[string]any, len(request.Params.Arguments))
+		for key, value := range request.Params.Arguments {
+			values[key] = value
+		}
+		for _, argument := range mcpPrompt.Arguments {
+			if _, ok := values[argument.Name]; argument.Required && !ok {
+				return nil, fmt.Errorf("missing required argument %q of prompt %s", argument.Name, name)
+			}
+		}
+		// Templates fail on missing variables, so optional arguments without a partial value default to ""
+		for _, variable := range options.optionalArguments {
+			_, hasValue := values[variable]
+			_, hasPartial := partialVariables[variable]
+			if !hasValue && !hasPartial {
+				values[variable] = ""
+			}
+		}
+
+		options.logger.Debug("ServerPrompt formatting LangchainGo prompt template", "prompt_name", name)
+		chatMessages, err := template.FormatMessages(values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to format prompt %s: %w", name, err)
+		}
+
+		messages := make([]mcp.PromptMessage, 0, len(chatMessages))
+		for _, chatMessage := range chatMessages {
+			message, err := convertLangchainMessageToMCPPromptMessage(chatMessage)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert message of prompt %s: %w", name, err)
+			}
+			messages = append(messages, message)
+		}
+		return mcp.NewGetPromptResult(options.description, messages), nil
+	}
+
+	return server.ServerPrompt{Prompt: mcpPrompt, Handler: handler}
+}
+
+// convertLangchainMessageToMCPPromptMessage converts a LangchainGo message to an MCP prompt message.
+func convertLangchainMessageToMCPPromptMessage(message llms.ChatMessage) (mcp.PromptMessage, error) {
+	switch message.GetType() {
+	case llms.ChatMessageTypeHuman, llms.ChatMessageTypeGeneric, llms.ChatMessageTypeSystem:
+		return mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(message.GetContent())), nil
+	case llms.ChatMessageTypeAI:
+		return mcp.NewPromptMessage(mcp.RoleAssistant, mcp.NewTextContent(message.GetContent())), nil
+	default:
+		return mcp.PromptMessage{}, fmt.Errorf("unsupported message type: %s", message.GetType())
+	}
+}
+
+// AddPrompt registers a LangchainGo prompt template as a prompt on an MCP server.
+func AddPrompt(s *server.MCPServer, name string, template prompts.MessageFormatter, opts ...PromptOption) {
+	s.AddPrompts(NewServerPrompt(name, template, opts...))
+}
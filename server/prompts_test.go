@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+
+	lcgomcp "github.com/akihiro-fukuchi/langchaingo-mcp-adapters/prompt"
+)
+
+func newReviewTemplate() prompts.ChatPromptTemplate {
+	template := prompts.NewChatPromptTemplate([]prompts.MessageFormatter{
+		prompts.NewSystemMessagePromptTemplate("You review {{.language}} code in a {{.tone}} tone.", []string{"language", "tone"}),
+		prompts.NewHumanMessagePromptTemplate("Review this code:\n{{.code}}", []string{"code"}),
+		prompts.NewAIMessagePromptTemplate("Sure, reviewing the {{.language}} code.", []string{"language"}),
+	})
+	template.PartialVariables = map[string]any{"tone": "friendly"}
+	return template
+}
+
+func TestAddPrompt(t *testing.T) {
+	s := server.NewMCPServer("test", "1.0.0")
+	AddPrompt(s, "code_review", newReviewTemplate(),
+		WithPromptDescription("Reviews code"),
+		WithArgumentDescriptions(map[string]string{"code": "Code to review"}),
+		WithOptionalArguments("language"),
+	)
+	mcpClient := newInProcessClient(t, s)
+
+	listResult, err := mcpClient.ListPrompts(context.Background(), mcp.ListPromptsRequest{})
+	require.NoError(t, err)
+	require.Len(t, listResult.Prompts, 1)
+	assert.Equal(t, "code_review", listResult.Prompts[0].Name)
+	assert.Equal(t, "Reviews code", listResult.Prompts[0].Description)
+	assert.Equal(t, []mcp.PromptArgument{
+		{Name: "code", Description: "Code to review", Required: true},
+		{Name: "language"},
+		{Name: "tone"},
+	}, listResult.Prompts[0].Arguments)
+
+	request := mcp.GetPromptRequest{}
+	request.Params.Name = "code_review"
+	request.Params.Arguments = map[string]string{"code": "x := 1", "language": "Go"}
+	getResult, err := mcpClient.GetPrompt(context.Background(), request)
+
+	require.NoError(t, err)
+	assert.Equal(t, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent("You review Go code in a friendly tone.")),
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent("Review this code:\nx := 1")),
+		mcp.NewPromptMessage(mcp.RoleAssistant, mcp.NewTextContent("Sure, reviewing the Go code.")),
+	}, getResult.Messages)
+}
+
+func TestAddPrompt_OptionalArgumentOmitted(t *testing.T) {
+	s := server.NewMCPServer("test", "1.0.0")
+	AddPrompt(s, "code_review", newReviewTemplate(), WithOptionalArguments("language"))
+	mcpClient := newInProcessClient(t, s)
+
+	request := mcp.GetPromptRequest{}
+	request.Params.Name = "code_review"
+	request.Params.Arguments = map[string]string{"code": "x := 1"}
+	getResult, err := mcpClient.GetPrompt(context.Background(), request)
+
+	require.NoError(t, err)
+	require.Len(t, getResult.Messages, 3)
+	assert.Equal(t, mcp.NewTextContent("You review  code in a friendly tone."), getResult.Messages[0].Content,
+		"The omitted argument should be empty and the partial value kept")
+}
+
+func TestAddPrompt_LoadMCPPrompt(t *testing.T) {
+	s := server.NewMCPServer("test", "1.0.0")
+	AddPrompt(s, "greet", prompts.NewHumanMessagePromptTemplate("Hello {{.name}}!", []string{"name"}))
+	mcpClient := newInProcessClient(t, s)
+
+	messages, err := lcgomcp.LoadMCPPrompt(context.Background(), mcpClient, "greet", map[string]string{"name": "Gopher"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []llms.ChatMessage{llms.HumanChatMessage{Content: "Hello Gopher!"}}, messages)
+}
+
+func TestAddPrompt_Errors(t *testing.T) {
+	s := server.NewMCPServer("test", "1.0.0")
+	AddPrompt(s, "code_review", newReviewTemplate())
+	mcpClient := newInProcessClient(t, s)
+
+	request := mcp.GetPromptRequest{}
+	request.Params.Name = "code_review"
+	request.Params.Arguments = map[string]string{"language": "Go"}
+	_, err := mcpClient.GetPrompt(context.Background(), request)
+	assert.ErrorContains(t, err, `missing required argument "code"`)
+}
+
+func TestConvertLangchainMessageToMCPPromptMessage_Unsupported(t *testing.T) {
+	_, err := convertLangchainMessageToMCPPromptMessage(llms.ToolChatMessage{Content: "42"})
+	assert.ErrorContains(t, err, "unsupported message type")
+}
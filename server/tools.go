@@ -0,0 +1,115 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// InputArgumentName is the name of the single string argument of tools without an input schema.
+const InputArgumentName = "input"
+
+// InputSchemaProvider can be implemented by a tools.Tool to describe its input as a
+// JSON Schema object. The MCP arguments are then passed to Call as a JSON string.
+type InputSchemaProvider interface {
+	InputSchema() map[string]any
+}
+
+// ToolOption configures a tool created by NewServerTool.
+type ToolOption func(*toolOptions)
+
+type toolOptions struct {
+	logger *slog.Logger
+}
+
+// WithToolLogger sets the logger of the tool. Defaults to slog.Default().
+func WithToolLogger(logger *slog.Logger) ToolOption {
+	return func(o *toolOptions) {
+		o.logger = logger
+	}
+}
+
+// NewServerTool converts a LangchainGo tool into an MCP tool and its handler.
+// Tools that do not implement InputSchemaProvider take a single string argument named "input".
+// Errors returned by Call are reported as tool results with IsError set.
+func NewServerTool(lcTool tools.Tool, opts ...ToolOption) (server.ServerTool, error) {
+	options := &toolOptions{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(options)
+	}
+	logger := options.logger.With("tool_name", lcTool.Name())
+
+	provider, hasSchema := lcTool.(InputSchemaProvider)
+
+	var mcpTool mcp.Tool
+	if hasSchema {
+		schema, err := json.Marshal(provider.InputSchema())
+		if err != nil {
+			return server.ServerTool{}, fmt.Errorf("failed to encode input schema of tool %s: %w", lcTool.Name(), err)
+		}
+		mcpTool = mcp.NewToolWithRawSchema(lcTool.Name(), lcTool.Description(), schema)
+	} else {
+		mcpTool = mcp.NewTool(lcTool.Name(),
+			mcp.WithDescription(lcTool.Description()),
+			mcp.WithString(InputArgumentName, mcp.Required(), mcp.Description("Input for the tool")),
+		)
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		input, err := toolInput(request, hasSchema)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		logger.Debug("ServerTool calling LangchainGo tool")
+		output, err := lcTool.Call(ctx, input)
+		if err != nil {
+			logger.Debug("ServerTool LangchainGo tool returned error", "error", err)
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	}
+
+	return server.ServerTool{Tool: mcpTool, Handler: handler}, nil
+}
+
+// toolInput builds the string input for Call from the MCP arguments.
+func toolInput(request mcp.CallToolRequest, hasSchema bool) (string, error) {
+	if hasSchema {
+		arguments := request.GetRawArguments()
+		if arguments == nil {
+			arguments = map[string]any{}
+		}
+		raw, err := json.Marshal(arguments)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode arguments: %w", err)
+		}
+		return string(raw), nil
+	}
+
+	input, err := request.RequireString(InputArgumentName)
+	if err != nil {
+		return "", err
+	}
+	return input, nil
+}
+
+// AddTools registers LangchainGo tools on an MCP server, so they can be served over
+// stdio, SSE or Streamable HTTP. Use NewServerTool to configure the tools with options.
+func AddTools(s *server.MCPServer, lcTools ...tools.Tool) error {
+	serverTools := make([]server.ServerTool, 0, len(lcTools))
+	for _, lcTool := range lcTools {
+		serverTool, err := NewServerTool(lcTool)
+		if err != nil {
+			return err
+		}
+		serverTools = append(serverTools, serverTool)
+	}
+	s.AddTools(serverTools...)
+	return nil
+}
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/prompts"
+
+	lcgomcptool "github.com/akihiro-fukuchi/langchaingo-mcp-adapters/tool"
+)
+
+// --- Fakes ---
+
+type FakeTool struct {
+	name   string
+	output string
+	err    error
+	schema map[string]any
+
+	input string
+}
+
+func (f *FakeTool) Name() string        { return f.name }
+func (f *FakeTool) Description() string { return "Fake tool " + f.name }
+func (f *FakeTool) Call(ctx context.Context, input string) (string, error) {
+	f.input = input
+	return f.output, f.err
+}
+
+type FakeSchemaTool struct {
+	FakeTool
+}
+
+func (f *FakeSchemaTool) InputSchema() map[string]any { return f.schema }
+
+// newInProcessClient starts an initialized in-process client for s.
+func newInProcessClient(t *testing.T, s *server.MCPServer) *client.Client {
+	t.Helper()
+	mcpClient, err := client.NewInProcessClient(s)
+	require.NoError(t, err)
+	require.NoError(t, mcpClient.Start(context.Background()))
+	t.Cleanup(func() { _ = mcpClient.Close() })
+
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	_, err = mcpClient.Initialize(context.Background(), initRequest)
+	require.NoError(t, err)
+	return mcpClient
+}
+
+func callTool(t *testing.T, mcpClient client.MCPClient, name string, arguments map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	request := mcp.CallToolRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = arguments
+	result, err := mcpClient.CallTool(context.Background(), request)
+	require.NoError(t, err)
+	return result
+}
+
+// --- Tests ---
+
+func TestAddTools_StringInput(t *testing.T) {
+	echo := &FakeTool{name: "echo", output: "echoed"}
+	s := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(false))
+	require.NoError(t, AddTools(s, echo))
+	mcpClient := newInProcessClient(t, s)
+
+	listResult, err := mcpClient.ListTools(context.Background(), mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	require.Len(t, listResult.Tools, 1)
+	assert.Equal(t, "echo", listResult.Tools[0].Name)
+	assert.Equal(t, "Fake tool echo", listResult.Tools[0].Description)
+	assert.Contains(t, listResult.Tools[0].InputSchema.Properties, InputArgumentName)
+	assert.Equal(t, []string{InputArgumentName}, listResult.Tools[0].InputSchema.Required)
+
+	result := callTool(t, mcpClient, "echo", map[string]any{"input": "hello"})
+
+	assert.False(t, result.IsError)
+	assert.Equal(t, []mcp.Content{mcp.NewTextContent("echoed")}, result.Content)
+	assert.Equal(t, "hello", echo.input)
+}
+
+func TestAddTools_SchemaInput(t *testing.T) {
+	search := &FakeSchemaTool{FakeTool{name: "search", output: "3 results", schema: map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"query": map[string]any{"type": "string"},
+			"limit": map[string]any{"type": "integer"},
+		},
+		"required": []string{"query"},
+	}}}
+	s := server.NewMCPServer("test", "1.0.0")
+	require.NoError(t, AddTools(s, search))
+	mcpClient := newInProcessClient(t, s)
+
+	listResult, err := mcpClient.ListTools(context.Background(), mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	require.Len(t, listResult.Tools, 1)
+	assert.Contains(t, listResult.Tools[0].InputSchema.Properties, "query")
+	assert.Contains(t, listResult.Tools[0].InputSchema.Properties, "limit")
+
+	result := callTool(t, mcpClient, "search", map[string]any{"query": "mcp", "limit": 3})
+
+	assert.False(t, result.IsError)
+	assert.JSONEq(t, `{"query":"mcp","limit":3}`, search.input)
+}
+
+func TestAddTools_Errors(t *testing.T) {
+	failing := &FakeTool{name: "failing", err: errors.New("quota exceeded")}
+	s := server.NewMCPServer("test", "1.0.0")
+	require.NoError(t, AddTools(s, failing))
+	mcpClient := newInProcessClient(t, s)
+
+	result := callTool(t, mcpClient, "failing", map[string]any{"input": "x"})
+	assert.True(t, result.IsError)
+	assert.Equal(t, []mcp.Content{mcp.NewTextContent("quota exceeded")}, result.Content)
+
+	result = callTool(t, mcpClient, "failing", map[string]any{})
+	assert.True(t, result.IsError, "A missing input argument should be reported as a tool error")
+}
+
+func TestServerLoggers(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	toolServer, err := NewServerTool(&FakeTool{name: "echo", output: "hi"}, WithToolLogger(logger))
+	require.NoError(t, err)
+	chain := &FakeChain{inputKeys: []string{"input"}, outputKeys: []string{"output"}, outputs: map[string]any{"output": "done"}}
+	s := server.NewMCPServer("test", "1.0.0")
+	s.AddTools(toolServer, NewChainTool("agent", "Runs the agent", chain, WithChainLogger(logger)))
+	AddPrompt(s, "greet", prompts.NewHumanMessagePromptTemplate("Hello {{.name}}!", []string{"name"}), WithPromptLogger(logger))
+	AddVectorStore(s, "docs", &FakeVectorStore{}, WithVectorStoreLogger(logger))
+	mcpClient := newInProcessClient(t, s)
+
+	callTool(t, mcpClient, "echo", map[string]any{InputArgumentName: "hi"})
+	callTool(t, mcpClient, "agent", map[string]any{"input": "go"})
+	callTool(t, mcpClient, DefaultSearchToolName, map[string]any{"query": "go"})
+	request := mcp.GetPromptRequest{}
+	request.Params.Name = "greet"
+	request.Params.Arguments = map[string]string{"name": "Gopher"}
+	_, err = mcpClient.GetPrompt(context.Background(), request)
+	require.NoError(t, err)
+
+	for _, message := range []string{"ServerTool calling", "ChainTool calling", "ServerPrompt formatting", "VectorStore searching"} {
+		assert.Contains(t, buf.String(), message)
+	}
+}
+
+func TestAddTools_InvalidSchema(t *testing.T) {
+	invalid := &FakeSchemaTool{FakeTool{name: "invalid", schema: map[string]any{"bad": make(chan int)}}}
+	s := server.NewMCPServer("test", "1.0.0")
+
+	err := AddTools(s, invalid)
+
+	assert.ErrorContains(t, err, "failed to encode input schema of tool invalid")
+}
+
+func TestAddTools_RoundTrip(t *testing.T) {
+	echo := &FakeTool{name: "echo", output: "pong"}
+	s := server.NewMCPServer("test", "1.0.0")
+	require.NoError(t, AddTools(s, echo))
+	mcpClient := newInProcessClient(t, s)
+
+	loadedTools, err := lcgomcptool.LoadMCPTools(context.Background(), mcpClient)
+	require.NoError(t, err)
+	require.Len(t, loadedTools, 1)
+
+	output, err := loadedTools[0].Call(context.Background(), "ping")
+
+	require.NoError(t, err)
+	assert.Equal(t, "pong", output)
+	assert.Equal(t, "ping", echo.input)
+}
@@ -0,0 +1,236 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+const (
+	// DefaultSearchToolName is the name of the search tool registered by AddVectorStore.
+	DefaultSearchToolName = "search"
+	// DocumentURIScheme is the URI scheme of the document resources published by AddVectorStore.
+	DocumentURIScheme = "vectorstore"
+
+	defaultNumDocuments         = 4
+	defaultMaxNumDocuments      = 50
+	defaultMaxDocumentResources = 1000
+)
+
+// VectorStoreOption configures the adapter created by AddVectorStore.
+type VectorStoreOption func(*vectorStoreOptions)
+
+type vectorStoreOptions struct {
+	toolName             string
+	description          string
+	defaultNumDocuments  int
+	maxNumDocuments      int
+	idKey                string
+	maxDocumentResources int
+	storeOptions         []vectorstores.Option
+	logger               *slog.Logger
+}
+
+// WithSearchToolName sets the name of the search tool. Defaults to DefaultSearchToolName.
+func WithSearchToolName(name string) VectorStoreOption {
+	return func(o *vectorStoreOptions) {
+		o.toolName = name
+	}
+}
+
+// WithSearchToolDescription sets the description of the search tool.
+func WithSearchToolDescription(description string) VectorStoreOption {
+	return func(o *vectorStoreOptions) {
+		o.description = description
+	}
+}
+
+// WithNumDocuments sets the number of documents returned when the client does not pass k,
+// and the largest k it may ask for.
+func WithNumDocuments(defaultK, maxK int) VectorStoreOption {
+	return func(o *vectorStoreOptions) {
+		o.defaultNumDocuments = defaultK
+		o.maxNumDocuments = maxK
+	}
+}
+
+// WithDocumentIDKey sets the metadata key holding a unique document ID. Documents without
+// it are identified by a hash of their content and metadata. Defaults to "id".
+func WithDocumentIDKey(key string) VectorStoreOption {
+	return func(o *vectorStoreOptions) {
+		o.idKey = key
+	}
+}
+
+// WithMaxDocumentResources sets how many retrieved documents stay published as resources.
+// The least recently retrieved documents are removed first. Defaults to 1000.
+func WithMaxDocumentResources(n int) VectorStoreOption {
+	return func(o *vectorStoreOptions) {
+		o.maxDocumentResources = n
+	}
+}
+
+// WithVectorStoreOptions sets options passed to SimilaritySearch on every search,
+// e.g. vectorstores.WithNameSpace.
+func WithVectorStoreOptions(options ...vectorstores.Option) VectorStoreOption {
+	return func(o *vectorStoreOptions) {
+		o.storeOptions = append(o.storeOptions, options...)
+	}
+}
+
+// WithVectorStoreLogger sets the logger of the search tool. Defaults to slog.Default().
+func WithVectorStoreLogger(logger *slog.Logger) VectorStoreOption {
+	return func(o *vectorStoreOptions) {
+		o.logger = logger
+	}
+}
+
+// vectorStoreAdapter serves searches on a vector store and publishes the retrieved documents.
+type vectorStoreAdapter struct {
+	s       *server.MCPServer
+	name    string
+	store   vectorstores.VectorStore
+	options *vectorStoreOptions
+
+	mu        sync.Mutex
+	published []string // resource URIs, least recently retrieved first
+}
+
+// AddVectorStore registers a search tool backed by SimilaritySearch of store on an MCP server.
+// The tool takes a query, the number of documents k, a score threshold and store specific
+// filters. Every retrieved document is published as a text resource with the stable URI
+// vectorstore://<name>/documents/<id> and linked from the tool result, so clients can read
+// it again with resources/read.
+func AddVectorStore(s *server.MCPServer, name string, store vectorstores.VectorStore, opts ...VectorStoreOption) {
+	options := &vectorStoreOptions{
+		toolName:             DefaultSearchToolName,
+		description:          fmt.Sprintf("Searches the %s knowledge base for documents relevant to a query", name),
+		defaultNumDocuments:  defaultNumDocuments,
+		maxNumDocuments:      defaultMaxNumDocuments,
+		idKey:                "id",
+		maxDocumentResources: defaultMaxDocumentResources,
+		logger:               slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	adapter := &vectorStoreAdapter{s: s, name: name, store: store, options: options}
+	searchTool := mcp.NewTool(options.toolName,
+		mcp.WithDescription(options.description),
+		mcp.WithString("query", mcp.Required(), mcp.Description("Text to search for")),
+		mcp.WithNumber("k", mcp.Description("Number of documents to return"),
+			mcp.DefaultNumber(float64(options.defaultNumDocuments)), mcp.Min(1), mcp.Max(float64(options.maxNumDocuments))),
+		mcp.WithNumber("score_threshold", mcp.Description("Minimum similarity score of returned documents"), mcp.Min(0), mcp.Max(1)),
+		mcp.WithObject("filters", mcp.Description("Metadata filters, in the format of the vector store")),
+	)
+	s.AddTool(searchTool, adapter.search)
+}
+
+func (a *vectorStoreAdapter) search(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query, err := request.RequireString("query")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	k := request.GetInt("k", a.options.defaultNumDocuments)
+	if k < 1 || k > a.options.maxNumDocuments {
+		return mcp.NewToolResultError(fmt.Sprintf("k must be between 1 and %d", a.options.maxNumDocuments)), nil
+	}
+
+	storeOptions := append([]vectorstores.Option{}, a.options.storeOptions...)
+	arguments := request.GetArguments()
+	if _, ok := arguments["score_threshold"]; ok {
+		storeOptions = append(storeOptions, vectorstores.WithScoreThreshold(float32(request.GetFloat("score_threshold", 0))))
+	}
+	if filters, ok := arguments["filters"]; ok && filters != nil {
+		storeOptions = append(storeOptions, vectorstores.WithFilters(filters))
+	}
+
+	a.options.logger.Debug("VectorStore searching", "tool_name", a.options.toolName, "k", k)
+	docs, err := a.store.SimilaritySearch(ctx, query, k, storeOptions...)
+	if err != nil {
+		a.options.logger.Debug("VectorStore search returned error", "tool_name", a.options.toolName, "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	type searchResult struct {
+		URI      string         `json:"uri"`
+		Content  string         `json:"content"`
+		Metadata map[string]any `json:"metadata,omitempty"`
+		Score    float32        `json:"score"`
+	}
+	results := make([]searchResult, 0, len(docs))
+	links := make([]mcp.Content, 0, len(docs))
+	for _, doc := range docs {
+		resource := a.publish(doc)
+		results = append(results, searchResult{URI: resource.URI, Content: doc.PageContent, Metadata: doc.Metadata, Score: doc.Score})
+		links = append(links, mcp.NewResourceLink(resource.URI, resource.Name, resource.Description, resource.MIMEType))
+	}
+
+	structured := map[string]any{"documents": results}
+	fallback, err := json.Marshal(structured)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode search results: %w", err)
+	}
+	result := mcp.NewToolResultStructured(structured, string(fallback))
+	result.Content = append(result.Content, links...)
+	return result, nil
+}
+
+// publish registers doc as a resource, replacing a previous registration of the same document.
+func (a *vectorStoreAdapter) publish(doc schema.Document) mcp.Resource {
+	id := a.documentID(doc)
+	uri := fmt.Sprintf("%s://%s/documents/%s", DocumentURIScheme, url.PathEscape(a.name), url.PathEscape(id))
+	name := id
+	if source, ok := doc.Metadata["source"].(string); ok && source != "" {
+		name = source
+	}
+	resource := mcp.NewResource(uri, name,
+		mcp.WithResourceDescription(fmt.Sprintf("Document retrieved from the %s knowledge base", a.name)),
+		mcp.WithMIMEType("text/plain"),
+	)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i, published := range a.published {
+		if published == uri {
+			a.published = append(a.published[:i], a.published[i+1:]...)
+			break
+		}
+	}
+	a.published = append(a.published, uri)
+	if evict := len(a.published) - a.options.maxDocumentResources; evict > 0 {
+		a.s.DeleteResources(a.published[:evict]...)
+		a.published = a.published[evict:]
+	}
+
+	a.s.AddResource(resource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return []mcp.ResourceContents{mcp.TextResourceContents{URI: uri, MIMEType: "text/plain", Text: doc.PageContent}}, nil
+	})
+	return resource
+}
+
+// documentID returns the ID stored in the metadata of doc, or a hash of its content and metadata.
+func (a *vectorStoreAdapter) documentID(doc schema.Document) string {
+	if id, ok := doc.Metadata[a.options.idKey]; ok && id != nil {
+		return fmt.Sprint(id)
+	}
+
+	// encoding/json sorts map keys, so equal documents always hash the same.
+	metadata, _ := json.Marshal(doc.Metadata)
+	hash := sha256.New()
+	hash.Write([]byte(doc.PageContent))
+	hash.Write([]byte{0})
+	hash.Write(metadata)
+	return hex.EncodeToString(hash.Sum(nil)[:16])
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+// --- Fakes ---
+
+type FakeVectorStore struct {
+	docs []schema.Document
+	err  error
+
+	query   string
+	k       int
+	options vectorstores.Options
+}
+
+func (f *FakeVectorStore) AddDocuments(ctx context.Context, docs []schema.Document, options ...vectorstores.Option) ([]string, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *FakeVectorStore) SimilaritySearch(ctx context.Context, query string, k int, options ...vectorstores.Option) ([]schema.Document, error) {
+	f.query = query
+	f.k = k
+	f.options = vectorstores.Options{}
+	for _, opt := range options {
+		opt(&f.options)
+	}
+	if len(f.docs) > k {
+		return f.docs[:k], f.err
+	}
+	return f.docs, f.err
+}
+
+func readResource(t *testing.T, mcpClient interface {
+	ReadResource(context.Context, mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error)
+}, uri string) (*mcp.ReadResourceResult, error) {
+	t.Helper()
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = uri
+	return mcpClient.ReadResource(context.Background(), request)
+}
+
+// --- Tests ---
+
+func TestAddVectorStore_Search(t *testing.T) {
+	store := &FakeVectorStore{docs: []schema.Document{
+		{PageContent: "Go is a programming language.", Metadata: map[string]any{"id": "go-intro", "source": "go.md"}, Score: 0.9},
+		{PageContent: "MCP connects models to tools.", Metadata: map[string]any{"topic": "mcp"}, Score: 0.7},
+	}}
+	s := server.NewMCPServer("test", "1.0.0")
+	AddVectorStore(s, "kb", store, WithVectorStoreOptions(vectorstores.WithNameSpace("docs")))
+	mcpClient := newInProcessClient(t, s)
+
+	listResult, err := mcpClient.ListTools(context.Background(), mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	require.Len(t, listResult.Tools, 1)
+	assert.Equal(t, DefaultSearchToolName, listResult.Tools[0].Name)
+	assert.Equal(t, []string{"query"}, listResult.Tools[0].InputSchema.Required)
+
+	result := callTool(t, mcpClient, "search", map[string]any{
+		"query":           "what is go",
+		"k":               2,
+		"score_threshold": 0.5,
+		"filters":         map[string]any{"topic": "go"},
+	})
+
+	require.False(t, result.IsError)
+	assert.Equal(t, "what is go", store.query)
+	assert.Equal(t, 2, store.k)
+	assert.Equal(t, "docs", store.options.NameSpace)
+	assert.InDelta(t, 0.5, store.options.ScoreThreshold, 1e-6)
+	assert.Equal(t, map[string]any{"topic": "go"}, store.options.Filters)
+
+	require.Len(t, result.Content, 3)
+	first := result.Content[1].(mcp.ResourceLink)
+	assert.Equal(t, "vectorstore://kb/documents/go-intro", first.URI)
+	assert.Equal(t, "go.md", first.Name)
+	second := result.Content[2].(mcp.ResourceLink)
+	assert.Regexp(t, `^vectorstore://kb/documents/[0-9a-f]{32}$`, second.URI)
+
+	documents := result.StructuredContent.(map[string]any)["documents"].([]any)
+	require.Len(t, documents, 2)
+	assert.Equal(t, first.URI, documents[0].(map[string]any)["uri"])
+	assert.Equal(t, "Go is a programming language.", documents[0].(map[string]any)["content"])
+
+	readResult, err := readResource(t, mcpClient, second.URI)
+	require.NoError(t, err)
+	require.Len(t, readResult.Contents, 1)
+	assert.Equal(t, "MCP connects models to tools.", readResult.Contents[0].(mcp.TextResourceContents).Text)
+
+	again := callTool(t, mcpClient, "search", map[string]any{"query": "mcp"})
+	assert.Equal(t, second.URI, again.Content[2].(mcp.ResourceLink).URI, "Document URIs must be stable across searches")
+	assert.Equal(t, defaultNumDocuments, store.k)
+
+	resources, err := mcpClient.ListResources(context.Background(), mcp.ListResourcesRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resources.Resources, 2)
+}
+
+func TestAddVectorStore_MaxDocumentResources(t *testing.T) {
+	store := &FakeVectorStore{}
+	s := server.NewMCPServer("test", "1.0.0")
+	AddVectorStore(s, "kb", store, WithMaxDocumentResources(2), WithDocumentIDKey("key"))
+	mcpClient := newInProcessClient(t, s)
+
+	for _, key := range []string{"a", "b", "c"} {
+		store.docs = []schema.Document{{PageContent: key, Metadata: map[string]any{"key": key}}}
+		callTool(t, mcpClient, "search", map[string]any{"query": key})
+	}
+
+	_, err := readResource(t, mcpClient, "vectorstore://kb/documents/a")
+	assert.Error(t, err, "The least recently retrieved document should be evicted")
+	readResult, err := readResource(t, mcpClient, "vectorstore://kb/documents/c")
+	require.NoError(t, err)
+	assert.Equal(t, "c", readResult.Contents[0].(mcp.TextResourceContents).Text)
+}
+
+func TestAddVectorStore_Errors(t *testing.T) {
+	store := &FakeVectorStore{err: errors.New("index offline")}
+	s := server.NewMCPServer("test", "1.0.0")
+	AddVectorStore(s, "kb", store, WithSearchToolName("kb_search"), WithNumDocuments(2, 5))
+	mcpClient := newInProcessClient(t, s)
+
+	result := callTool(t, mcpClient, "kb_search", map[string]any{})
+	assert.True(t, result.IsError)
+
+	result = callTool(t, mcpClient, "kb_search", map[string]any{"query": "x", "k": 6})
+	assert.True(t, result.IsError)
+	assert.Equal(t, []mcp.Content{mcp.NewTextContent("k must be between 1 and 5")}, result.Content)
+
+	result = callTool(t, mcpClient, "kb_search", map[string]any{"query": "x"})
+	assert.True(t, result.IsError)
+	assert.Equal(t, []mcp.Content{mcp.NewTextContent("index offline")}, result.Content)
+	assert.Equal(t, 2, store.k)
+}
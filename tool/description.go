@@ -0,0 +1,189 @@
+package tool
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/internal/jsonschema"
+)
+
+// DefaultDescriptionTemplate renders the description of a tool followed by its argument
+// signature and, if the schema provides examples or defaults, an example input.
+var DefaultDescriptionTemplate = template.Must(template.New("description").Parse(
+	`{{.Description}}{{if .Signature}} Input must be a JSON object: {{.Signature}}{{end}}{{if .Example}} Example: {{.Example}}{{end}}`,
+))
+
+// DescriptionData is the data passed to the description template.
+type DescriptionData struct {
+	Name        string
+	Description string // Description of the MCP tool
+	Signature   string // Compact argument signature, e.g. {"a": number, "unit"?: "c"|"f"}
+	Example     string // Example input built from schema examples and defaults, empty if none
+}
+
+// WithArgumentSignature appends a compact argument signature derived from the input schema
+// to Description, so that agents which only see the description (such as
+// agents.NewOneShotAgent) produce valid JSON input. Optional arguments are marked with "?".
+func WithArgumentSignature() Option {
+	return func(t *LangchainMCPTool) {
+		if t.descriptionTemplate == nil {
+			t.descriptionTemplate = DefaultDescriptionTemplate
+		}
+	}
+}
+
+// WithDescriptionTemplate renders Description with tmpl, executed with DescriptionData.
+// It implies WithArgumentSignature.
+func WithDescriptionTemplate(tmpl *template.Template) Option {
+	return func(t *LangchainMCPTool) {
+		t.descriptionTemplate = tmpl
+	}
+}
+
+// WithMaxDescriptionLength limits the rendered description to maxLength bytes. Descriptions
+// that are too long are rendered without the example first, and truncated if still too long.
+func WithMaxDescriptionLength(maxLength int) Option {
+	return func(t *LangchainMCPTool) {
+		t.maxDescriptionLength = maxLength
+	}
+}
+
+// renderDescription returns the description of the tool enriched with its argument signature,
+// or the plain description if no template is configured or rendering fails.
+func (t *LangchainMCPTool) renderDescription() string {
+	if t.descriptionTemplate == nil {
+		return t.mcpTool.Description
+	}
+
+	var schema any = t.mcpTool.InputSchema
+	if t.mcpTool.RawInputSchema != nil {
+		schema = t.mcpTool.RawInputSchema
+	}
+	normalized, err := jsonschema.Normalize(schema)
+	if err != nil {
+		t.logger.Warn("LangchainMCPTool failed to read input schema for description", "error", err)
+		return t.mcpTool.Description
+	}
+
+	data := DescriptionData{
+		Name:        t.mcpTool.Name,
+		Description: t.mcpTool.Description,
+		Signature:   signature(normalized),
+	}
+	if example := exampleValue(normalized); example != nil {
+		if raw, err := json.Marshal(example); err == nil {
+			data.Example = string(raw)
+		}
+	}
+
+	description, err := t.executeDescriptionTemplate(data)
+	if err != nil {
+		t.logger.Warn("LangchainMCPTool failed to render description", "error", err)
+		return t.mcpTool.Description
+	}
+	if t.maxDescriptionLength <= 0 || len(description) <= t.maxDescriptionLength {
+		return description
+	}
+
+	if data.Example != "" {
+		data.Example = ""
+		if shorter, err := t.executeDescriptionTemplate(data); err == nil {
+			description = shorter
+		}
+	}
+	return truncate(description, t.maxDescriptionLength)
+}
+
+func (t *LangchainMCPTool) executeDescriptionTemplate(data DescriptionData) (string, error) {
+	var b strings.Builder
+	if err := t.descriptionTemplate.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// truncate shortens s to at most maxLength bytes, ending with "..." and keeping runes intact.
+func truncate(s string, maxLength int) string {
+	if len(s) <= maxLength {
+		return s
+	}
+	const ellipsis = "..."
+	if maxLength <= len(ellipsis) {
+		return ellipsis[:maxLength]
+	}
+	return s[:runeStart(s, maxLength-len(ellipsis))] + ellipsis
+}
+
+// signature renders a schema as a compact, JSON-like type expression.
+func signature(schema map[string]any) string {
+	if enum, ok := schema["enum"].([]any); ok && len(enum) > 0 {
+		values := make([]string, 0, len(enum))
+		for _, value := range enum {
+			raw, _ := json.Marshal(value)
+			values = append(values, string(raw))
+		}
+		return strings.Join(values, "|")
+	}
+
+	switch jsonschema.Type(schema) {
+	case "object":
+		properties, _ := schema["properties"].(map[string]any)
+		if len(properties) == 0 {
+			return "object"
+		}
+		required := jsonschema.Required(schema)
+		names := make([]string, 0, len(properties))
+		for name := range properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fields := make([]string, 0, len(names))
+		for _, name := range names {
+			marker := "?"
+			if required[name] {
+				marker = ""
+			}
+			property, _ := properties[name].(map[string]any)
+			fields = append(fields, fmt.Sprintf("%q%s: %s", name, marker, signature(property)))
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	case "array":
+		items, _ := schema["items"].(map[string]any)
+		return "[" + signature(items) + "]"
+	case "":
+		return "any"
+	default:
+		return jsonschema.Type(schema)
+	}
+}
+
+// exampleValue builds an example from the "examples" and "default" keywords of schema and
+// its properties, or returns nil if the schema provides none.
+func exampleValue(schema map[string]any) any {
+	if examples, ok := schema["examples"].([]any); ok && len(examples) > 0 {
+		return examples[0]
+	}
+	if value, ok := schema["default"]; ok {
+		return value
+	}
+	if jsonschema.Type(schema) != "object" {
+		return nil
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	example := map[string]any{}
+	for name, property := range properties {
+		property, _ := property.(map[string]any)
+		if value := exampleValue(property); value != nil {
+			example[name] = value
+		}
+	}
+	if len(example) == 0 {
+		return nil
+	}
+	return example
+}
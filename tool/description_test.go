@@ -0,0 +1,98 @@
+package tool
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+)
+
+func forecastTool() mcp.Tool {
+	return mcp.NewTool("get_forecast",
+		mcp.WithDescription("Gets the weather forecast."),
+		mcp.WithString("city", mcp.Required(), mcp.Description("City name")),
+		mcp.WithNumber("days", mcp.DefaultNumber(3)),
+		mcp.WithString("unit", mcp.Enum("celsius", "fahrenheit")),
+		mcp.WithArray("tags", mcp.WithStringItems()),
+		mcp.WithObject("location", mcp.Properties(map[string]any{
+			"lat": map[string]any{"type": "number"},
+		})),
+	)
+}
+
+func TestLangchainMCPTool_Description_Signature(t *testing.T) {
+	tests := []struct {
+		name     string
+		mcpTool  mcp.Tool
+		opts     []Option
+		expected string
+	}{
+		{
+			name:     "Disabled",
+			mcpTool:  forecastTool(),
+			expected: "Gets the weather forecast.",
+		},
+		{
+			name:    "Default template",
+			mcpTool: forecastTool(),
+			opts:    []Option{WithArgumentSignature()},
+			expected: `Gets the weather forecast. Input must be a JSON object: ` +
+				`{"city": string, "days"?: number, "location"?: {"lat"?: number}, "tags"?: [string], "unit"?: "celsius"|"fahrenheit"} ` +
+				`Example: {"days":3}`,
+		},
+		{
+			name: "Raw schema with examples",
+			mcpTool: mcp.NewToolWithRawSchema("add", "Adds numbers.", []byte(
+				`{"type":"object","properties":{"a":{"type":"integer"},"b":{"type":["integer","null"]}},"required":["a"],"examples":[{"a":1,"b":2}]}`,
+			)),
+			opts:     []Option{WithArgumentSignature()},
+			expected: `Adds numbers. Input must be a JSON object: {"a": integer, "b"?: integer} Example: {"a":1,"b":2}`,
+		},
+		{
+			name:    "Custom template",
+			mcpTool: forecastTool(),
+			opts: []Option{WithDescriptionTemplate(template.Must(template.New("").Parse(
+				`{{.Name}}({{.Signature}})`,
+			)))},
+			expected: `get_forecast({"city": string, "days"?: number, "location"?: {"lat"?: number}, "tags"?: [string], "unit"?: "celsius"|"fahrenheit"})`,
+		},
+		{
+			name:     "Max length drops example",
+			mcpTool:  mcp.NewTool("echo", mcp.WithDescription("Echoes."), mcp.WithString("text", mcp.Required(), mcp.DefaultString("hi"))),
+			opts:     []Option{WithArgumentSignature(), WithMaxDescriptionLength(60)},
+			expected: `Echoes. Input must be a JSON object: {"text": string}`,
+		},
+		{
+			name:     "Max length truncates",
+			mcpTool:  forecastTool(),
+			opts:     []Option{WithArgumentSignature(), WithMaxDescriptionLength(40)},
+			expected: `Gets the weather forecast. Input must...`,
+		},
+		{
+			name:     "Template error falls back",
+			mcpTool:  forecastTool(),
+			opts:     []Option{WithDescriptionTemplate(template.Must(template.New("").Parse(`{{.Missing}}`)))},
+			expected: "Gets the weather forecast.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lcTool := NewLangchainMCPTool(tt.mcpTool, nil, nil, tt.opts...)
+			assert.Equal(t, tt.expected, lcTool.Description())
+			if n := lcTool.maxDescriptionLength; n > 0 {
+				assert.LessOrEqual(t, len(lcTool.Description()), n)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "abc", truncate("abc", 3))
+	assert.Equal(t, "a...", truncate("abcdef", 4))
+	assert.Equal(t, "..", truncate("abcdef", 2))
+	assert.Equal(t, "...", truncate("日本語", 5), "does not split runes")
+	assert.True(t, strings.HasPrefix(truncate("日本語です", 9), "日本"))
+}
@@ -0,0 +1,58 @@
+package tool
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	. "github.com/ovechkin-dm/mockio/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLangchainMCPTool_Call_Logging(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []Option
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "Payloads redacted by default",
+			contains:    []string{"tool_name=secret-tool", "server_name=vault", "input=[redacted]", "output=[redacted]"},
+			notContains: []string{"hunter2", "s3cr3t"},
+		},
+		{
+			name:     "Payload logging enabled",
+			opts:     []Option{WithPayloadLogging()},
+			contains: []string{"tool_name=secret-tool", "server_name=vault", "hunter2", "output=s3cr3t"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUp(t)
+			mockClient := Mock[MockMCPClient]()
+			When(mockClient.CallTool(Any[context.Context](), Any[mcp.CallToolRequest]())).
+				ThenReturn(mcp.NewToolResultText("s3cr3t"), nil)
+
+			var logs bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			opts := append([]Option{WithLogger(logger), WithServerName("vault")}, tt.opts...)
+			lcTool := NewLangchainMCPTool(mcp.Tool{Name: "secret-tool"}, mockClient, nil, opts...)
+
+			output, err := lcTool.Call(context.Background(), `{"password": "hunter2"}`)
+			require.NoError(t, err)
+			assert.Equal(t, "s3cr3t", output)
+
+			for _, s := range tt.contains {
+				assert.Contains(t, logs.String(), s)
+			}
+			for _, s := range tt.notContains {
+				assert.NotContains(t, logs.String(), s)
+			}
+		})
+	}
+}
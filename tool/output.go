@@ -0,0 +1,209 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/tmc/langchaingo/llms"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/internal/telemetry"
+)
+
+// TruncationStrategy selects how an output exceeding its OutputLimit is shortened.
+type TruncationStrategy string
+
+const (
+	TruncateHead     TruncationStrategy = "head"      // Keep the beginning of the output
+	TruncateHeadTail TruncationStrategy = "head_tail" // Keep the beginning and the end of the output
+	Summarize        TruncationStrategy = "summarize" // Replace the output with a summary by OutputLimit.Model
+)
+
+const (
+	// DefaultTokenizerModel is the model whose tokenizer counts tokens for OutputLimit.MaxTokens.
+	DefaultTokenizerModel = "gpt-4"
+	// DefaultSummaryInputBytes is the part of an output sent to OutputLimit.Model for a summary.
+	DefaultSummaryInputBytes = 64 * 1024
+)
+
+// OutputLimit limits the size of the output returned by Call, so that large results such
+// as log dumps do not exceed the context window of the model. Outputs within the limits
+// are returned unchanged; longer ones are shortened with Strategy and marked as truncated.
+type OutputLimit struct {
+	MaxBytes  int                // Maximum size of the output in bytes, 0 for no limit
+	MaxTokens int                // Maximum number of tokens of the output, 0 for no limit
+	Strategy  TruncationStrategy // Defaults to TruncateHead
+
+	// CountTokens counts the tokens of a text for MaxTokens. Defaults to llms.CountTokens
+	// with TokenizerModel, or DefaultTokenizerModel if it is empty.
+	CountTokens    func(text string) int
+	TokenizerModel string
+
+	// Model summarizes outputs with the Summarize strategy. If it fails, or its summary
+	// exceeds the limits, the output is truncated with TruncateHeadTail instead.
+	Model             llms.Model
+	SummaryInputBytes int // Maximum bytes of the output sent to Model, defaults to DefaultSummaryInputBytes
+}
+
+// OutputTruncation reports that the output of a tool call exceeded its OutputLimit.
+type OutputTruncation struct {
+	ToolName       string
+	Strategy       TruncationStrategy // Strategy applied, which is TruncateHeadTail if a summary failed
+	OriginalBytes  int
+	OriginalTokens int // Zero if MaxTokens is not set
+	Bytes          int // Size of the output returned to the agent
+}
+
+// TruncationCallbackHandler can be implemented by a callbacks.Handler passed to
+// NewLangchainMCPTool to observe outputs that were shortened, e.g. to record their size.
+type TruncationCallbackHandler interface {
+	HandleToolOutputTruncated(ctx context.Context, truncation OutputTruncation)
+}
+
+// WithOutputLimit limits the output of the tool, or of every tool loaded by LoadMCPTools.
+func WithOutputLimit(limit OutputLimit) Option {
+	return func(t *LangchainMCPTool) {
+		t.outputLimit = &limit
+	}
+}
+
+// WithToolOutputLimits limits the output of the tools named in limits, overriding WithOutputLimit.
+func WithToolOutputLimits(limits map[string]OutputLimit) Option {
+	return func(t *LangchainMCPTool) {
+		t.toolOutputLimits = limits
+	}
+}
+
+// countTokens counts the tokens of text with the tokenizer of l.
+func (l *OutputLimit) countTokens(text string) int {
+	if l.CountTokens != nil {
+		return l.CountTokens(text)
+	}
+	model := l.TokenizerModel
+	if model == "" {
+		model = DefaultTokenizerModel
+	}
+	return llms.CountTokens(model, text)
+}
+
+// fits reports whether text is within the limits of l.
+func (l *OutputLimit) fits(text string) bool {
+	if l.MaxBytes > 0 && len(text) > l.MaxBytes {
+		return false
+	}
+	return l.MaxTokens <= 0 || l.countTokens(text) <= l.MaxTokens
+}
+
+// limitOutput applies the output limit of the tool to output, and reports the original size
+// if it was exceeded.
+func (t *LangchainMCPTool) limitOutput(ctx context.Context, output string) string {
+	limit := t.outputLimit
+	if limit == nil || limit.fits(output) {
+		return output
+	}
+
+	truncation := OutputTruncation{
+		ToolName:      t.Name(),
+		Strategy:      limit.Strategy,
+		OriginalBytes: len(output),
+	}
+	if truncation.Strategy == "" {
+		truncation.Strategy = TruncateHead
+	}
+	if limit.MaxTokens > 0 {
+		truncation.OriginalTokens = limit.countTokens(output)
+	}
+
+	var limited string
+	if truncation.Strategy == Summarize {
+		summary, err := t.summarize(ctx, output)
+		if err != nil {
+			t.logger.Warn("LangchainMCPTool.Call failed to summarize output, truncating it instead", "error", err)
+			truncation.Strategy = TruncateHeadTail
+		} else {
+			limited = summary
+		}
+	}
+	if truncation.Strategy != Summarize {
+		limited = truncateOutput(output, truncation.Strategy, truncationMarker(len(output)), limit.fits)
+	}
+	truncation.Bytes = len(limited)
+
+	t.logger.Debug("LangchainMCPTool.Call limited output", "strategy", truncation.Strategy,
+		"original_bytes", truncation.OriginalBytes, "original_tokens", truncation.OriginalTokens, "bytes", truncation.Bytes)
+	trace.SpanFromContext(ctx).SetAttributes(
+		telemetry.OriginalResultSizeKey.Int(truncation.OriginalBytes),
+		telemetry.TruncationKey.String(string(truncation.Strategy)),
+	)
+	if handler, ok := t.callbacks.(TruncationCallbackHandler); ok {
+		handler.HandleToolOutputTruncated(ctx, truncation)
+	}
+	return limited
+}
+
+// summarize asks the model of the output limit to summarize output. It fails if the summary
+// does not fit the limit either.
+func (t *LangchainMCPTool) summarize(ctx context.Context, output string) (string, error) {
+	limit := t.outputLimit
+	if limit.Model == nil {
+		return "", errors.New("no model to summarize with")
+	}
+	inputBytes := limit.SummaryInputBytes
+	if inputBytes <= 0 {
+		inputBytes = DefaultSummaryInputBytes
+	}
+	input := truncateOutput(output, TruncateHeadTail, truncationMarker(len(output)), func(text string) bool {
+		return len(text) <= inputBytes
+	})
+
+	prompt := fmt.Sprintf("The output of the tool %q is too long to be used as is. "+
+		"Summarize it concisely, keeping identifiers, numbers and errors that may be needed to act on it.\n\n%s",
+		t.Name(), input)
+	summary, err := llms.GenerateFromSinglePrompt(ctx, limit.Model, prompt)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate summary: %w", err)
+	}
+	summary += fmt.Sprintf("\n[output summarized from %d bytes]", len(output))
+	if !limit.fits(summary) {
+		return "", fmt.Errorf("summary of %d bytes exceeds the output limit", len(summary))
+	}
+	return summary, nil
+}
+
+// truncationMarker returns the marker that replaces the omitted part of an output of
+// originalBytes bytes.
+func truncationMarker(originalBytes int) string {
+	return fmt.Sprintf("\n[... output truncated from %d bytes ...]\n", originalBytes)
+}
+
+// truncateOutput keeps as much of text as fits, with marker in place of the omitted part: the
+// beginning for TruncateHead, or the beginning and the end for TruncateHeadTail. Text is only
+// cut at rune boundaries. If not even the marker fits, it is returned alone.
+func truncateOutput(text string, strategy TruncationStrategy, marker string, fits func(string) bool) string {
+	build := func(keep int) string {
+		if strategy != TruncateHeadTail {
+			return text[:runeStart(text, keep)] + marker
+		}
+		head := runeStart(text, (keep+1)/2)
+		tail := runeStart(text, len(text)-keep/2)
+		if tail < head {
+			tail = head
+		}
+		return text[:head] + marker + text[tail:]
+	}
+	// The largest number of bytes kept for which the result fits
+	keep := sort.Search(len(text)+1, func(keep int) bool { return !fits(build(keep)) }) - 1
+	return build(max(keep, 0))
+}
+
+// runeStart returns the start of the rune of text containing byte i, so that text is not cut
+// within a multi-byte character.
+func runeStart(text string, i int) int {
+	for i > 0 && i < len(text) && !utf8.RuneStart(text[i]) {
+		i--
+	}
+	return i
+}
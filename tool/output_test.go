@@ -0,0 +1,140 @@
+package tool
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	. "github.com/ovechkin-dm/mockio/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms/fake"
+)
+
+type MockTruncationCallbackHandler struct {
+	MockCallbackHandler
+}
+
+func (m *MockTruncationCallbackHandler) HandleToolOutputTruncated(ctx context.Context, truncation OutputTruncation) {
+	m.Called(ctx, truncation)
+}
+
+// countWords stands in for a tokenizer, counting every word as a token.
+func countWords(text string) int {
+	return len(strings.Fields(text))
+}
+
+func TestTruncateOutput(t *testing.T) {
+	fitsBytes := func(n int) func(string) bool {
+		return func(text string) bool { return len(text) <= n }
+	}
+	tests := []struct {
+		name     string
+		text     string
+		strategy TruncationStrategy
+		fits     func(string) bool
+		expected string
+	}{
+		{"head", "0123456789", TruncateHead, fitsBytes(7), "0123[.]"},
+		{"head tail", "0123456789", TruncateHeadTail, fitsBytes(7), "01[.]89"},
+		{"head tail odd", "0123456789", TruncateHeadTail, fitsBytes(8), "012[.]89"},
+		{"runes", "日本語テキスト", TruncateHead, fitsBytes(10), "日本[.]"},
+		{"marker only", "0123456789", TruncateHead, fitsBytes(1), "[.]"},
+		{"tokens", "one two three four five six", TruncateHeadTail, func(text string) bool { return countWords(text) <= 3 }, "one two[.]ive six"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, truncateOutput(tt.text, tt.strategy, "[.]", tt.fits))
+		})
+	}
+}
+
+func TestLangchainMCPTool_Call_OutputLimit(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+	mockHandler := new(MockTruncationCallbackHandler)
+	logs := "BEGIN " + strings.Repeat("line ", 1000) + "END"
+	When(mockClient.CallTool(Any[context.Context](), Any[mcp.CallToolRequest]())).
+		ThenReturn(mcp.NewToolResultText(logs), nil)
+
+	lcTool := NewLangchainMCPTool(mcp.Tool{Name: "logs"}, mockClient, mockHandler,
+		WithOutputLimit(OutputLimit{MaxBytes: 1000}),
+		WithToolOutputLimits(map[string]OutputLimit{"logs": {MaxBytes: 200, Strategy: TruncateHeadTail}}),
+	)
+	mockHandler.On("HandleToolStart", mock.Anything, `{}`).Return()
+	mockHandler.On("HandleToolOutputTruncated", mock.Anything, OutputTruncation{
+		ToolName: "logs", Strategy: TruncateHeadTail, OriginalBytes: len(logs), Bytes: 200,
+	}).Return()
+	mockHandler.On("HandleToolEnd", mock.Anything, mock.Anything).Return()
+
+	output, err := lcTool.Call(context.Background(), `{}`)
+
+	require.NoError(t, err)
+	assert.Len(t, output, 200, "The per-tool limit should override the global one")
+	assert.True(t, strings.HasPrefix(output, "BEGIN line"))
+	assert.True(t, strings.HasSuffix(output, "line END"))
+	assert.Contains(t, output, "[... output truncated from 5009 bytes ...]")
+	mockHandler.AssertExpectations(t)
+}
+
+func TestLangchainMCPTool_Call_OutputLimit_Tokens(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+	When(mockClient.CallTool(Any[context.Context](), Any[mcp.CallToolRequest]())).
+		ThenReturn(mcp.NewToolResultText(strings.Repeat("word ", 100)), nil)
+
+	limit := OutputLimit{MaxTokens: 20, CountTokens: countWords}
+	lcTool := NewLangchainMCPTool(mcp.Tool{Name: "words"}, mockClient, nil, WithOutputLimit(limit))
+
+	output, err := lcTool.Call(context.Background(), `{}`)
+
+	require.NoError(t, err)
+	assert.Equal(t, 20, countWords(output))
+	assert.Contains(t, output, "[... output truncated from 500 bytes ...]")
+
+	// Outputs within the limit are returned unchanged
+	short := NewLangchainMCPTool(mcp.Tool{Name: "words"}, mockClient, nil, WithOutputLimit(OutputLimit{MaxTokens: 100, CountTokens: countWords}))
+	output, err = short.Call(context.Background(), `{}`)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("word ", 100), output)
+}
+
+func TestLangchainMCPTool_Call_OutputLimit_Summarize(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+	When(mockClient.CallTool(Any[context.Context](), Any[mcp.CallToolRequest]())).
+		ThenReturn(mcp.NewToolResultText(strings.Repeat("ERROR disk full\n", 100)), nil)
+
+	model := fake.NewFakeLLM([]string{"The disk is full (100 errors).", strings.Repeat("too long ", 100)})
+	mockHandler := new(MockTruncationCallbackHandler)
+	lcTool := NewLangchainMCPTool(mcp.Tool{Name: "logs"}, mockClient, mockHandler,
+		WithOutputLimit(OutputLimit{MaxBytes: 200, Strategy: Summarize, Model: model}))
+	mockHandler.On("HandleToolStart", mock.Anything, `{}`).Return()
+	mockHandler.On("HandleToolOutputTruncated", mock.Anything, mock.Anything).Return()
+	mockHandler.On("HandleToolEnd", mock.Anything, mock.Anything).Return()
+
+	output, err := lcTool.Call(context.Background(), `{}`)
+
+	require.NoError(t, err)
+	assert.Equal(t, "The disk is full (100 errors).\n[output summarized from 1600 bytes]", output)
+
+	// A summary exceeding the limit is replaced by the truncated output
+	output, err = lcTool.Call(context.Background(), `{}`)
+
+	require.NoError(t, err)
+	assert.Len(t, output, 200)
+	assert.True(t, strings.HasPrefix(output, "ERROR disk full"))
+	truncations := []OutputTruncation{}
+	for _, call := range mockHandler.Calls {
+		if call.Method == "HandleToolOutputTruncated" {
+			truncations = append(truncations, call.Arguments.Get(1).(OutputTruncation))
+		}
+	}
+	assert.Equal(t, []OutputTruncation{
+		{ToolName: "logs", Strategy: Summarize, OriginalBytes: 1600, Bytes: len("The disk is full (100 errors).\n[output summarized from 1600 bytes]")},
+		{ToolName: "logs", Strategy: TruncateHeadTail, OriginalBytes: 1600, Bytes: 200},
+	}, truncations)
+}
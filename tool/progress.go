@@ -0,0 +1,143 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// MethodNotificationProgress is the JSON-RPC method used by servers to report progress.
+const MethodNotificationProgress = "notifications/progress"
+
+// Progress is a progress update reported by an MCP server for an in-flight tool call.
+type Progress struct {
+	ToolName string
+	Token    mcp.ProgressToken
+	Progress float64
+	Total    float64 // Zero if the server did not report a total
+	Message  string
+}
+
+// ProgressHandler receives progress updates for a tool call.
+type ProgressHandler func(ctx context.Context, progress Progress)
+
+// ProgressCallbackHandler can be implemented by a callbacks.Handler passed to
+// NewLangchainMCPTool to observe progress of tool calls, e.g. to drive a progress bar.
+type ProgressCallbackHandler interface {
+	HandleToolProgress(ctx context.Context, progress Progress)
+}
+
+type progressHandlerKey struct{}
+
+// ContextWithProgressHandler returns a copy of ctx that routes progress updates
+// for tool calls made with it to handler.
+func ContextWithProgressHandler(ctx context.Context, handler ProgressHandler) context.Context {
+	return context.WithValue(ctx, progressHandlerKey{}, handler)
+}
+
+// progressHandlerFromContext returns the handler set by ContextWithProgressHandler, if any.
+func progressHandlerFromContext(ctx context.Context) ProgressHandler {
+	handler, _ := ctx.Value(progressHandlerKey{}).(ProgressHandler)
+	return handler
+}
+
+// ProgressRouter dispatches notifications/progress received on a single MCP session
+// to the tool call that owns the progress token.
+// Register HandleNotification with the session's OnNotification once, and share the
+// router between all tools of that session via WithProgressRouter.
+type ProgressRouter struct {
+	mu       sync.Mutex
+	next     atomic.Uint64
+	handlers map[string]func(Progress)
+	logger   *slog.Logger
+}
+
+// RouterOption configures a ProgressRouter.
+type RouterOption func(*ProgressRouter)
+
+// WithRouterLogger sets the logger of the router. Defaults to slog.Default().
+func WithRouterLogger(logger *slog.Logger) RouterOption {
+	return func(r *ProgressRouter) {
+		r.logger = logger
+	}
+}
+
+// NewProgressRouter creates an empty ProgressRouter.
+func NewProgressRouter(opts ...RouterOption) *ProgressRouter {
+	r := &ProgressRouter{
+		handlers: make(map[string]func(Progress)),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.logger == nil {
+		r.logger = slog.Default()
+	}
+	return r
+}
+
+// register allocates a new progress token and routes matching notifications to fn
+// until the returned unregister function is called.
+func (r *ProgressRouter) register(toolName string, fn func(Progress)) (string, func()) {
+	token := fmt.Sprintf("%s-%d", toolName, r.next.Add(1))
+
+	r.mu.Lock()
+	r.handlers[token] = fn
+	r.mu.Unlock()
+
+	return token, func() {
+		r.mu.Lock()
+		delete(r.handlers, token)
+		r.mu.Unlock()
+	}
+}
+
+// HandleNotification routes a notifications/progress message to the registered call.
+// Other notifications and unknown tokens are ignored.
+func (r *ProgressRouter) HandleNotification(notification mcp.JSONRPCNotification) {
+	if notification.Method != MethodNotificationProgress {
+		return
+	}
+
+	progress, err := parseProgressNotification(notification)
+	if err != nil {
+		r.logger.Debug("ProgressRouter failed to parse progress notification", "error", err)
+		return
+	}
+	token, ok := progress.Token.(string)
+	if !ok {
+		return
+	}
+
+	r.mu.Lock()
+	fn, ok := r.handlers[token]
+	r.mu.Unlock()
+	if !ok {
+		r.logger.Debug("ProgressRouter received progress for unknown token", "token", token)
+		return
+	}
+	fn(progress)
+}
+
+// parseProgressNotification decodes the params of a notifications/progress message.
+func parseProgressNotification(notification mcp.JSONRPCNotification) (Progress, error) {
+	raw, err := json.Marshal(notification.Params)
+	if err != nil {
+		return Progress{}, err
+	}
+	var params mcp.ProgressNotification
+	if err := json.Unmarshal(raw, &params.Params); err != nil {
+		return Progress{}, err
+	}
+	return Progress{
+		Token:    params.Params.ProgressToken,
+		Progress: params.Params.Progress,
+		Total:    params.Params.Total,
+		Message:  params.Params.Message,
+	}, nil
+}
@@ -0,0 +1,128 @@
+package tool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	. "github.com/ovechkin-dm/mockio/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockProgressCallbackHandler struct {
+	MockCallbackHandler
+}
+
+func (m *MockProgressCallbackHandler) HandleToolProgress(ctx context.Context, progress Progress) {
+	m.Called(ctx, progress)
+}
+
+func progressNotification(token any, progress, total float64, message string) mcp.JSONRPCNotification {
+	notification := mcp.JSONRPCNotification{JSONRPC: mcp.JSONRPC_VERSION}
+	notification.Method = MethodNotificationProgress
+	notification.Params.AdditionalFields = map[string]any{
+		"progressToken": token,
+		"progress":      progress,
+		"total":         total,
+		"message":       message,
+	}
+	return notification
+}
+
+func TestProgressRouter_HandleNotification(t *testing.T) {
+	router := NewProgressRouter()
+
+	var received []Progress
+	token, unregister := router.register("indexer", func(p Progress) { received = append(received, p) })
+
+	router.HandleNotification(progressNotification(token, 1, 4, "step 1"))
+	router.HandleNotification(progressNotification("other-token", 2, 4, "ignored"))
+
+	otherMethod := progressNotification(token, 3, 4, "ignored")
+	otherMethod.Method = "notifications/message"
+	router.HandleNotification(otherMethod)
+
+	unregister()
+	router.HandleNotification(progressNotification(token, 4, 4, "after unregister"))
+
+	require.Len(t, received, 1)
+	assert.Equal(t, Progress{Token: token, Progress: 1, Total: 4, Message: "step 1"}, received[0])
+}
+
+func TestProgressRouter_UniqueTokens(t *testing.T) {
+	router := NewProgressRouter()
+
+	token1, unregister1 := router.register("tool", func(Progress) {})
+	token2, unregister2 := router.register("tool", func(Progress) {})
+	defer unregister1()
+	defer unregister2()
+
+	assert.NotEqual(t, token1, token2)
+}
+
+func TestLangchainMCPTool_Call_Progress(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+	mockHandler := new(MockProgressCallbackHandler)
+	router := NewProgressRouter()
+
+	var toolLevel, callLevel []Progress
+	lcTool := NewLangchainMCPTool(mcp.Tool{Name: "long-task"}, mockClient, mockHandler,
+		WithProgressRouter(router),
+		WithProgressHandler(func(ctx context.Context, p Progress) { toolLevel = append(toolLevel, p) }),
+	)
+
+	var sentToken mcp.ProgressToken
+	WhenDouble(mockClient.CallTool(Any[context.Context](), Any[mcp.CallToolRequest]())).
+		ThenAnswer(func(args []any) (*mcp.CallToolResult, error) {
+			request := args[1].(mcp.CallToolRequest)
+			require.NotNil(t, request.Params.Meta)
+			sentToken = request.Params.Meta.ProgressToken
+			router.HandleNotification(progressNotification(sentToken, 50, 100, "halfway"))
+			return mcp.NewToolResultText("done"), nil
+		})
+
+	expected := Progress{ToolName: "long-task", Progress: 50, Total: 100, Message: "halfway"}
+	mockHandler.On("HandleToolStart", mock.Anything, `{}`).Return()
+	mockHandler.On("HandleToolProgress", mock.Anything, mock.MatchedBy(func(p Progress) bool {
+		return p.ToolName == expected.ToolName && p.Progress == expected.Progress && p.Message == expected.Message
+	})).Return()
+	mockHandler.On("HandleToolEnd", mock.Anything, "done").Return()
+
+	ctx := ContextWithProgressHandler(context.Background(), func(ctx context.Context, p Progress) {
+		callLevel = append(callLevel, p)
+	})
+	output, err := lcTool.Call(ctx, `{}`)
+
+	require.NoError(t, err)
+	assert.Equal(t, "done", output)
+	expected.Token = sentToken
+	assert.Equal(t, []Progress{expected}, toolLevel)
+	assert.Equal(t, []Progress{expected}, callLevel)
+	mockHandler.AssertExpectations(t)
+
+	// The token is released once the call returns
+	router.mu.Lock()
+	assert.Empty(t, router.handlers)
+	router.mu.Unlock()
+}
+
+func TestLangchainMCPTool_Call_NoProgressRouter(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+	lcTool := NewLangchainMCPTool(mcp.Tool{Name: "plain"}, mockClient, nil)
+
+	WhenDouble(mockClient.CallTool(Any[context.Context](), Any[mcp.CallToolRequest]())).
+		ThenAnswer(func(args []any) (*mcp.CallToolResult, error) {
+			request := args[1].(mcp.CallToolRequest)
+			assert.Nil(t, request.Params.Meta)
+			return mcp.NewToolResultText("ok"), nil
+		})
+
+	output, err := lcTool.Call(context.Background(), `{}`)
+
+	require.NoError(t, err)
+	assert.Equal(t, "ok", output)
+}
@@ -0,0 +1,99 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/internal/jsonschema"
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/internal/telemetry"
+)
+
+// ErrNoStructuredContent is returned by CallStructured when a tool result has neither
+// structured content nor text that parses as JSON.
+var ErrNoStructuredContent = errors.New("tool returned no structured content")
+
+// OutputSchema returns the output schema declared by the MCP tool, or nil if it has none.
+func (t *LangchainMCPTool) OutputSchema() any {
+	if t.mcpTool.RawOutputSchema != nil {
+		return t.mcpTool.RawOutputSchema
+	}
+	if t.mcpTool.OutputSchema.Type != "" {
+		return t.mcpTool.OutputSchema
+	}
+	return nil
+}
+
+// CallStructured calls the MCP tool with arguments and returns its structuredContent,
+// validated against the output schema of the tool. Results without structuredContent fall
+// back to their text content parsed as JSON, as returned by tools predating output schemas.
+// Tool errors (isError) are returned as errors.
+func (t *LangchainMCPTool) CallStructured(ctx context.Context, arguments map[string]any) (_ any, err error) {
+	input, _ := json.Marshal(arguments)
+	ctx, span := t.startCallSpan(ctx, len(input))
+	defer func() { telemetry.End(span, t.redactError(err)) }()
+	if t.callbacks != nil {
+		t.callbacks.HandleToolStart(ctx, string(input))
+	}
+
+	structured, err := t.callStructured(ctx, arguments)
+	if err != nil {
+		t.logger.Error("LangchainMCPTool.CallStructured failed", "error", t.redactError(err))
+		if t.callbacks != nil {
+			t.callbacks.HandleToolError(ctx, err)
+		}
+		return nil, err
+	}
+
+	output, _ := json.Marshal(structured)
+	span.SetAttributes(telemetry.ResultSizeKey.Int(len(output)))
+	if t.callbacks != nil {
+		t.callbacks.HandleToolEnd(ctx, string(output))
+	}
+	return structured, nil
+}
+
+func (t *LangchainMCPTool) callStructured(ctx context.Context, arguments map[string]any) (any, error) {
+	t.logger.Debug("LangchainMCPTool.CallStructured calling MCP client...")
+	result, err := t.callTool(ctx, arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	text, toolErr := processCallToolResult(result)
+	if toolErr != nil {
+		return nil, t.executionError(toolErr)
+	}
+
+	structured := result.StructuredContent
+	if structured == nil {
+		if err := json.Unmarshal([]byte(text), &structured); err != nil || structured == nil {
+			return nil, fmt.Errorf("%w: %s", ErrNoStructuredContent, t.Name())
+		}
+	}
+
+	if err := jsonschema.Validate(t.OutputSchema(), structured); err != nil {
+		return nil, fmt.Errorf("structured content of tool %s does not match its output schema: %w", t.Name(), err)
+	}
+	return structured, nil
+}
+
+// CallTyped calls tool with arguments and decodes its structured result into T.
+// See LangchainMCPTool.CallStructured.
+func CallTyped[T any](ctx context.Context, tool *LangchainMCPTool, arguments map[string]any) (T, error) {
+	var typed T
+	structured, err := tool.CallStructured(ctx, arguments)
+	if err != nil {
+		return typed, err
+	}
+
+	raw, err := json.Marshal(structured)
+	if err != nil {
+		return typed, fmt.Errorf("failed to encode structured content of tool %s: %w", tool.Name(), err)
+	}
+	if err := json.Unmarshal(raw, &typed); err != nil {
+		return typed, fmt.Errorf("failed to decode structured content of tool %s into %T: %w", tool.Name(), typed, err)
+	}
+	return typed, nil
+}
@@ -0,0 +1,129 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	. "github.com/ovechkin-dm/mockio/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type Weather struct {
+	Temperature float64 `json:"temperature"`
+	Unit        string  `json:"unit"`
+}
+
+func weatherTool() mcp.Tool {
+	return mcp.NewTool("weather",
+		mcp.WithString("city", mcp.Required()),
+		mcp.WithOutputSchema[Weather](),
+	)
+}
+
+func weatherRequest() mcp.CallToolRequest {
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "weather"
+	request.Params.Arguments = map[string]any{"city": "Tokyo"}
+	return request
+}
+
+func TestLangchainMCPTool_CallStructured(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+	mockHandler := new(MockCallbackHandler)
+	When(mockClient.CallTool(Any[context.Context](), Equal(weatherRequest()))).
+		ThenReturn(mcp.NewToolResultStructured(map[string]any{"temperature": 21.5, "unit": "celsius"}, "21.5 celsius"), nil)
+	mockHandler.On("HandleToolStart", mock.Anything, `{"city":"Tokyo"}`).Return()
+	mockHandler.On("HandleToolEnd", mock.Anything, `{"temperature":21.5,"unit":"celsius"}`).Return()
+
+	lcTool := NewLangchainMCPTool(weatherTool(), mockClient, mockHandler)
+	require.NotNil(t, lcTool.OutputSchema())
+
+	structured, err := lcTool.CallStructured(context.Background(), map[string]any{"city": "Tokyo"})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"temperature": 21.5, "unit": "celsius"}, structured)
+	mockHandler.AssertExpectations(t)
+}
+
+func TestCallTyped(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+	When(mockClient.CallTool(Any[context.Context](), Equal(weatherRequest()))).
+		ThenReturn(mcp.NewToolResultStructured(map[string]any{"temperature": 21.5, "unit": "celsius"}, "21.5 celsius"), nil)
+
+	lcTool := NewLangchainMCPTool(weatherTool(), mockClient, nil)
+	weather, err := CallTyped[Weather](context.Background(), lcTool, map[string]any{"city": "Tokyo"})
+
+	require.NoError(t, err)
+	assert.Equal(t, Weather{Temperature: 21.5, Unit: "celsius"}, weather)
+}
+
+func TestLangchainMCPTool_CallStructured_TextFallback(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+	When(mockClient.CallTool(Any[context.Context](), Any[mcp.CallToolRequest]())).
+		ThenReturn(mcp.NewToolResultText(`{"temperature": 18, "unit": "celsius"}`), nil)
+
+	lcTool := NewLangchainMCPTool(mcp.NewTool("weather"), mockClient, nil)
+	assert.Nil(t, lcTool.OutputSchema())
+
+	weather, err := CallTyped[Weather](context.Background(), lcTool, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, Weather{Temperature: 18, Unit: "celsius"}, weather)
+}
+
+func TestLangchainMCPTool_CallStructured_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		result    *mcp.CallToolResult
+		err       error
+		expectErr string
+		expectIs  error
+	}{
+		{
+			name:      "Schema mismatch",
+			result:    mcp.NewToolResultStructuredOnly(map[string]any{"temperature": "warm"}),
+			expectErr: "does not match its output schema",
+		},
+		{
+			name:     "No structured content",
+			result:   mcp.NewToolResultText("sunny"),
+			expectIs: ErrNoStructuredContent,
+		},
+		{
+			name:      "Tool error",
+			result:    mcp.NewToolResultError("unknown city"),
+			expectErr: "unknown city",
+		},
+		{
+			name:      "Client error",
+			err:       errors.New("connection closed"),
+			expectErr: "connection closed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUp(t)
+			mockClient := Mock[MockMCPClient]()
+			When(mockClient.CallTool(Any[context.Context](), Any[mcp.CallToolRequest]())).ThenReturn(tt.result, tt.err)
+
+			lcTool := NewLangchainMCPTool(weatherTool(), mockClient, nil)
+			_, err := CallTyped[Weather](context.Background(), lcTool, map[string]any{"city": "Tokyo"})
+
+			require.Error(t, err)
+			if tt.expectErr != "" {
+				assert.ErrorContains(t, err, tt.expectErr)
+			}
+			if tt.expectIs != nil {
+				assert.ErrorIs(t, err, tt.expectIs)
+			}
+		})
+	}
+}
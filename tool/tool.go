@@ -3,16 +3,25 @@ package tool
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sort"
 	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/tmc/langchaingo/callbacks"
 	"github.com/tmc/langchaingo/tools"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/internal/telemetry"
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/metrics"
 )
 
 // LangchainMCPTool wraps an mcp.Tool to make it compatible with langchaingo/tools.Tool interface.
@@ -20,17 +29,153 @@ type LangchainMCPTool struct {
 	mcpTool   mcp.Tool
 	mcpClient client.MCPClient
 	callbacks callbacks.Handler // Optional callback handler
+
+	progress        *ProgressRouter // Optional router for notifications/progress
+	progressHandler ProgressHandler // Optional handler for progress of every call
+
+	descriptionTemplate  *template.Template // Optional template enriching the description
+	maxDescriptionLength int                // Maximum length of the rendered description, 0 for no limit
+	description          string             // Rendered description
+
+	serverName string           // Name of the server providing the tool, used in spans and metrics
+	telemetry  telemetry.Config // Tracing configuration
+	metrics    metrics.Recorder // Optional recorder of tool calls
+
+	logger      *slog.Logger // Logger bound to the tool and server names
+	logPayloads bool         // Whether arguments and results are logged
+
+	outputLimit      *OutputLimit           // Optional limit of the output of Call
+	toolOutputLimits map[string]OutputLimit // Limits by tool name, overriding outputLimit
 }
 
 var _ tools.Tool = (*LangchainMCPTool)(nil)
 
+// Option configures a LangchainMCPTool.
+type Option func(*LangchainMCPTool)
+
+// WithProgressRouter attaches a progress token to every call and receives the
+// matching progress notifications through router.
+func WithProgressRouter(router *ProgressRouter) Option {
+	return func(t *LangchainMCPTool) {
+		t.progress = router
+	}
+}
+
+// WithProgressHandler sets a handler that receives progress updates for every call of the tool.
+// It only takes effect together with WithProgressRouter.
+func WithProgressHandler(handler ProgressHandler) Option {
+	return func(t *LangchainMCPTool) {
+		t.progressHandler = handler
+	}
+}
+
+// WithCallbacksHandler sets the handler notified of the start, end, errors and, if it
+// implements ProgressCallbackHandler, progress of every call.
+func WithCallbacksHandler(handler callbacks.Handler) Option {
+	return func(t *LangchainMCPTool) {
+		t.callbacks = handler
+	}
+}
+
+// WithServerName records the name of the server providing the tool in traces and metrics.
+func WithServerName(serverName string) Option {
+	return func(t *LangchainMCPTool) {
+		t.serverName = serverName
+	}
+}
+
+// WithTracerProvider sets the OpenTelemetry tracer provider of the tool spans.
+// Defaults to the global tracer provider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(t *LangchainMCPTool) {
+		t.telemetry.TracerProvider = tp
+	}
+}
+
+// WithPropagator sets the propagator injecting the trace context into the _meta of tool calls.
+// Defaults to the global propagator.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(t *LangchainMCPTool) {
+		t.telemetry.Propagator = propagator
+	}
+}
+
+// WithLogger sets the logger of the tool. Its records carry the tool name in the tool_name
+// attribute, and the server name if WithServerName is set. Defaults to slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(t *LangchainMCPTool) {
+		t.logger = logger
+	}
+}
+
+// WithPayloadLogging includes the input, arguments and results of calls in debug logs.
+// They are replaced with RedactedPayload by default, as they may contain sensitive data.
+func WithPayloadLogging() Option {
+	return func(t *LangchainMCPTool) {
+		t.logPayloads = true
+	}
+}
+
+// WithMetrics records every tools/call request with recorder.
+func WithMetrics(recorder metrics.Recorder) Option {
+	return func(t *LangchainMCPTool) {
+		t.metrics = recorder
+	}
+}
+
 // NewLangchainMCPTool creates a new LangchainMCPTool wrapper.
-func NewLangchainMCPTool(mcpTool mcp.Tool, mcpClient client.MCPClient, handler callbacks.Handler) *LangchainMCPTool {
-	return &LangchainMCPTool{
+func NewLangchainMCPTool(mcpTool mcp.Tool, mcpClient client.MCPClient, handler callbacks.Handler, opts ...Option) *LangchainMCPTool {
+	t := &LangchainMCPTool{
 		mcpTool:   mcpTool,
 		mcpClient: mcpClient,
 		callbacks: handler,
 	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if t.logger == nil {
+		t.logger = slog.Default()
+	}
+	t.logger = t.logger.With("tool_name", mcpTool.Name)
+	if t.serverName != "" {
+		t.logger = t.logger.With("server_name", t.serverName)
+	}
+	if limit, ok := t.toolOutputLimits[mcpTool.Name]; ok {
+		t.outputLimit = &limit
+	}
+	t.description = t.renderDescription()
+	return t
+}
+
+// RedactedPayload replaces tool arguments and results in logs unless payload logging is enabled.
+const RedactedPayload = "[redacted]"
+
+// payload returns a log attribute for a tool argument or result, redacted unless
+// payload logging is enabled.
+func (t *LangchainMCPTool) payload(key string, value any) slog.Attr {
+	if !t.logPayloads {
+		return slog.String(key, RedactedPayload)
+	}
+	return slog.Any(key, value)
+}
+
+// payloadError is an error whose message contains a tool argument or result.
+type payloadError struct {
+	err      error
+	redacted string // Message without the payload
+}
+
+func (e payloadError) Error() string { return e.err.Error() }
+func (e payloadError) Unwrap() error { return e.err }
+
+// redactError returns err for spans and logs, without the tool arguments or results it
+// contains unless payload logging is enabled.
+func (t *LangchainMCPTool) redactError(err error) error {
+	var payloadErr payloadError
+	if t.logPayloads || !errors.As(err, &payloadErr) {
+		return err
+	}
+	return errors.New(payloadErr.redacted)
 }
 
 // Name returns the name of the MCP tool.
@@ -38,15 +183,27 @@ func (t *LangchainMCPTool) Name() string {
 	return t.mcpTool.Name
 }
 
-// Description returns the description of the MCP tool.
+// Description returns the description of the MCP tool, enriched with its argument
+// signature if WithArgumentSignature or WithDescriptionTemplate is set.
 func (t *LangchainMCPTool) Description() string {
-	return t.mcpTool.Description
+	return t.description
 }
 
 // Call executes the MCP tool.
 // The input string is expected to be a JSON object representing the arguments.
 func (t *LangchainMCPTool) Call(ctx context.Context, input string) (string, error) {
-	slog.Debug("LangchainMCPTool.Call received input", "tool_name", t.Name(), "input", input)
+	ctx, span := t.startCallSpan(ctx, len(input))
+	output, err := t.call(ctx, input)
+	span.SetAttributes(telemetry.ResultSizeKey.Int(len(output)))
+	telemetry.End(span, t.redactError(err))
+	// Failures are returned as output, so that agents can react to them
+	return output, nil
+}
+
+// call executes the MCP tool for Call. It returns the output for the agent, and the
+// reason of the failure if the call failed.
+func (t *LangchainMCPTool) call(ctx context.Context, input string) (string, error) {
+	t.logger.Debug("LangchainMCPTool.Call received input", t.payload("input", input))
 	if t.callbacks != nil {
 		t.callbacks.HandleToolStart(ctx, input)
 	}
@@ -55,7 +212,7 @@ func (t *LangchainMCPTool) Call(ctx context.Context, input string) (string, erro
 	var arguments map[string]interface{}
 	jsonErr := json.Unmarshal([]byte(input), &arguments)
 	if jsonErr != nil {
-		slog.Debug("LangchainMCPTool.Call input is not valid JSON, attempting other parsing methods", "tool_name", t.Name(), "input", input, "error", jsonErr)
+		t.logger.Debug("LangchainMCPTool.Call input is not valid JSON, attempting other parsing methods", t.payload("input", input), "error", jsonErr)
 
 		// Attempt 1: Comma-separated numbers for multi-arg number tools
 		parts := strings.Split(input, ",")
@@ -78,7 +235,7 @@ func (t *LangchainMCPTool) Call(ctx context.Context, input string) (string, erro
 		// and the number of parts matches the number of *required* arguments.
 		// This is an assumption based on common agent behavior.
 		if len(parts) > 1 && isMultiNumberTool && len(parts) == len(requiredArgs) {
-			slog.Debug("LangchainMCPTool.Call attempting to parse as comma-separated numbers", "tool_name", t.Name(), "input", input, "required_args", requiredArgs, "sorted_arg_names", argNames)
+			t.logger.Debug("LangchainMCPTool.Call attempting to parse as comma-separated numbers", t.payload("input", input), "required_args", requiredArgs, "sorted_arg_names", argNames)
 			parsedArgs := make(map[string]interface{})
 			parseSuccess := true
 			// Assign parts based on the *sorted* order of required argument names
@@ -91,7 +248,7 @@ func (t *LangchainMCPTool) Call(ctx context.Context, input string) (string, erro
 				trimmedPart := strings.TrimSpace(part)
 				num, parseErr := strconv.ParseFloat(trimmedPart, 64)
 				if parseErr != nil {
-					slog.Debug("LangchainMCPTool.Call failed to parse part as float64", "tool_name", t.Name(), "part", trimmedPart, "error", parseErr)
+					t.logger.Debug("LangchainMCPTool.Call failed to parse part as float64", t.payload("part", trimmedPart), "error", parseErr)
 					parseSuccess = false
 					break
 				}
@@ -99,20 +256,20 @@ func (t *LangchainMCPTool) Call(ctx context.Context, input string) (string, erro
 				if i < len(sortedRequiredArgs) {
 					targetArgName := sortedRequiredArgs[i]
 					parsedArgs[targetArgName] = num
-					slog.Debug("LangchainMCPTool.Call parsed part for arg", "tool_name", t.Name(), "part_index", i, "part_value", trimmedPart, "parsed_number", num, "arg_name", targetArgName)
+					t.logger.Debug("LangchainMCPTool.Call parsed part for arg", "part_index", i, t.payload("part_value", trimmedPart), "arg_name", targetArgName)
 				} else {
 					// This case should ideally not be reached due to the len(parts) == len(requiredArgs) check
-					slog.Warn("LangchainMCPTool.Call more parts than required arguments, skipping part", "tool_name", t.Name(), "part_value", trimmedPart)
+					t.logger.Warn("LangchainMCPTool.Call more parts than required arguments, skipping part", t.payload("part_value", trimmedPart))
 					parseSuccess = false
 					break
 				}
 			}
 			if parseSuccess {
-				slog.Debug("LangchainMCPTool.Call successfully parsed comma-separated numbers", "tool_name", t.Name())
+				t.logger.Debug("LangchainMCPTool.Call successfully parsed comma-separated numbers")
 				arguments = parsedArgs
 				jsonErr = nil // Clear the JSON error as we succeeded with another method
 			} else {
-				slog.Debug("LangchainMCPTool.Call failed to parse all parts as comma-separated numbers", "tool_name", t.Name())
+				t.logger.Debug("LangchainMCPTool.Call failed to parse all parts as comma-separated numbers")
 			}
 		}
 
@@ -121,7 +278,7 @@ func (t *LangchainMCPTool) Call(ctx context.Context, input string) (string, erro
 			singleArgName := argNames[0]
 			propSchema, ok := t.mcpTool.InputSchema.Properties[singleArgName].(map[string]interface{})
 			if ok && propSchema["type"] == "string" {
-				slog.Debug("LangchainMCPTool.Call fallback successful, using input as single string argument", "tool_name", t.Name(), "arg_name", singleArgName)
+				t.logger.Debug("LangchainMCPTool.Call fallback successful, using input as single string argument", "arg_name", singleArgName)
 				arguments = map[string]interface{}{singleArgName: input}
 				jsonErr = nil // Clear the JSON error
 			}
@@ -129,56 +286,127 @@ func (t *LangchainMCPTool) Call(ctx context.Context, input string) (string, erro
 
 		// If all parsing attempts failed
 		if jsonErr != nil {
-			err := fmt.Errorf("failed to parse tool input '%s': not valid JSON and other parsing attempts failed: %w", input, jsonErr)
-			slog.Error("LangchainMCPTool.Call all parsing attempts failed", "tool_name", t.Name(), "error", err)
+			err := payloadError{
+				err:      fmt.Errorf("failed to parse tool input '%s': not valid JSON and other parsing attempts failed: %w", input, jsonErr),
+				redacted: fmt.Sprintf("failed to parse tool input '%s': not valid JSON and other parsing attempts failed", RedactedPayload),
+			}
+			t.logger.Error("LangchainMCPTool.Call all parsing attempts failed", "error", t.redactError(err))
 			if t.callbacks != nil {
 				t.callbacks.HandleToolError(ctx, err)
 			}
-			return fmt.Sprintf("Error: %s", err.Error()), nil
+			return fmt.Sprintf("Error: %s", err.Error()), err
 		}
 	}
 
-	slog.Debug("LangchainMCPTool.Call parsed arguments", "tool_name", t.Name(), "arguments", arguments)
-
-	// Create the MCP CallToolRequest
-	request := mcp.CallToolRequest{}
-	request.Params.Name = t.mcpTool.Name
-	request.Params.Arguments = arguments
+	t.logger.Debug("LangchainMCPTool.Call parsed arguments", t.payload("arguments", arguments))
 
 	// Call the MCP tool via the client
-	slog.Debug("LangchainMCPTool.Call calling MCP client...", "tool_name", t.Name())
-	result, err := t.mcpClient.CallTool(ctx, request)
+	t.logger.Debug("LangchainMCPTool.Call calling MCP client...")
+	result, err := t.callTool(ctx, arguments)
 	if err != nil {
-		err = fmt.Errorf("failed to call MCP tool %s: %w", t.mcpTool.Name, err)
-		slog.Error("LangchainMCPTool.Call MCP client call failed", "tool_name", t.Name(), "error", err)
+		t.logger.Error("LangchainMCPTool.Call MCP client call failed", "error", err)
 		if t.callbacks != nil {
 			t.callbacks.HandleToolError(ctx, err)
 		}
-		// Return error message as string output, nil error
-		return fmt.Sprintf("Error calling tool %s: %s", t.mcpTool.Name, err.Error()), nil
+		return fmt.Sprintf("Error calling tool %s: %s", t.mcpTool.Name, err.Error()), err
 	}
-	slog.Debug("LangchainMCPTool.Call MCP client call successful", "tool_name", t.Name(), "result", result)
+	t.logger.Debug("LangchainMCPTool.Call MCP client call successful", t.payload("result", result))
 
 	// Process the result
 	output, toolErr := processCallToolResult(result) // toolErr will contain the error message if result.IsError is true
+	output = t.limitOutput(ctx, output)
 	if toolErr != nil {
 		// The error message from the tool is already in 'output' (processCallToolResult returns the text content even on error)
-		slog.Error("LangchainMCPTool.Call tool execution resulted in error", "tool_name", t.Name(), "error", toolErr, "output", output)
+		t.logger.Error("LangchainMCPTool.Call tool execution resulted in error", t.payload("output", output))
+		err := t.executionError(toolErr)
 		if t.callbacks != nil {
-			t.callbacks.HandleToolError(ctx, fmt.Errorf("tool %s execution failed: %w", t.Name(), toolErr))
+			t.callbacks.HandleToolError(ctx, err)
 		}
-		// Return the error message from the tool as the output string, nil error
-		return output, nil
+		// Return the error message from the tool as the output string
+		return output, err
 	}
 
-	slog.Debug("LangchainMCPTool.Call returning output", "tool_name", t.Name(), "output", output)
+	t.logger.Debug("LangchainMCPTool.Call returning output", t.payload("output", output))
 	if t.callbacks != nil {
 		t.callbacks.HandleToolEnd(ctx, output)
 	}
 	return output, nil
 }
 
-// processCallToolResult extracts the text content from the MCP tool result.
+// executionError wraps the error of a result with isError set, whose message is the output of the tool.
+func (t *LangchainMCPTool) executionError(toolErr error) error {
+	return payloadError{
+		err:      fmt.Errorf("tool %s execution failed: %w", t.Name(), toolErr),
+		redacted: fmt.Sprintf("tool %s execution failed: %s", t.Name(), RedactedPayload),
+	}
+}
+
+// callTool sends tools/call with arguments, requesting progress notifications if a router is available.
+func (t *LangchainMCPTool) callTool(ctx context.Context, arguments map[string]any) (*mcp.CallToolResult, error) {
+	request := mcp.CallToolRequest{}
+	request.Params.Name = t.mcpTool.Name
+	request.Params.Arguments = arguments
+
+	if t.progress != nil {
+		token, unregister := t.progress.register(t.Name(), func(progress Progress) {
+			t.handleProgress(ctx, progress)
+		})
+		defer unregister()
+		request.Params.Meta = &mcp.Meta{ProgressToken: token}
+	}
+	request.Params.Meta = t.telemetry.InjectMeta(ctx, request.Params.Meta)
+
+	start := time.Now()
+	result, err := t.mcpClient.CallTool(ctx, request)
+	if t.metrics != nil {
+		outcome := metrics.OutcomeSuccess
+		if err != nil {
+			outcome = metrics.OutcomeTransportError
+		} else if result.IsError {
+			outcome = metrics.OutcomeToolError
+		}
+		t.metrics.RecordToolCall(ctx, t.serverName, t.Name(), outcome, time.Since(start))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to call MCP tool %s: %w", t.mcpTool.Name, err)
+	}
+	trace.SpanFromContext(ctx).SetAttributes(telemetry.IsErrorKey.Bool(result.IsError))
+	return result, nil
+}
+
+// startCallSpan starts the span of a tools/call request with arguments of argumentsSize bytes.
+func (t *LangchainMCPTool) startCallSpan(ctx context.Context, argumentsSize int) (context.Context, trace.Span) {
+	attributes := []attribute.KeyValue{
+		telemetry.MethodNameKey.String(string(mcp.MethodToolsCall)),
+		telemetry.ToolNameKey.String(t.Name()),
+		telemetry.ArgumentsSizeKey.Int(argumentsSize),
+	}
+	if t.serverName != "" {
+		attributes = append(attributes, telemetry.ServerNameKey.String(t.serverName))
+	}
+	return t.telemetry.Tracer().Start(ctx, string(mcp.MethodToolsCall)+" "+t.Name(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+}
+
+// handleProgress forwards a progress update to the tool, context and callback handlers.
+func (t *LangchainMCPTool) handleProgress(ctx context.Context, progress Progress) {
+	progress.ToolName = t.Name()
+	t.logger.Debug("LangchainMCPTool received progress", "progress", progress.Progress, "total", progress.Total)
+	if t.progressHandler != nil {
+		t.progressHandler(ctx, progress)
+	}
+	if handler := progressHandlerFromContext(ctx); handler != nil {
+		handler(ctx, progress)
+	}
+	if handler, ok := t.callbacks.(ProgressCallbackHandler); ok {
+		handler.HandleToolProgress(ctx, progress)
+	}
+}
+
+// processCallToolResult extracts the text content from the MCP tool result, or the
+// structured content as compact JSON if there is no text.
 // If result.IsError is true, it returns the extracted text and a non-nil error containing that text.
 func processCallToolResult(result *mcp.CallToolResult) (string, error) {
 	var outputBuilder strings.Builder
@@ -195,6 +423,15 @@ func processCallToolResult(result *mcp.CallToolResult) (string, error) {
 
 	outputText := outputBuilder.String()
 
+	// Tools returning only structured content are rendered as compact JSON
+	if outputBuilder.Len() == 0 && result.StructuredContent != nil {
+		structured, err := json.Marshal(result.StructuredContent)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode structured content: %w", err)
+		}
+		outputText = string(structured)
+	}
+
 	if result.IsError {
 		// Return the output text AND a non-nil error containing the same text
 		return outputText, fmt.Errorf("%s", outputText)
@@ -204,18 +441,33 @@ func processCallToolResult(result *mcp.CallToolResult) (string, error) {
 }
 
 // LoadMCPTools fetches the list of tools from the MCP server and converts them
-// into LangchainGo compatible tools.
-func LoadMCPTools(ctx context.Context, mcpClient client.MCPClient) ([]tools.Tool, error) {
+// into LangchainGo compatible tools. The options are applied to every loaded tool.
+func LoadMCPTools(ctx context.Context, mcpClient client.MCPClient, opts ...Option) (_ []tools.Tool, err error) {
+	// Apply the options once to find the tracing configuration and callbacks handler
+	config := &LangchainMCPTool{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	attributes := []attribute.KeyValue{telemetry.MethodNameKey.String(string(mcp.MethodToolsList))}
+	if config.serverName != "" {
+		attributes = append(attributes, telemetry.ServerNameKey.String(config.serverName))
+	}
+	ctx, span := config.telemetry.Tracer().Start(ctx, string(mcp.MethodToolsList),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+	defer func() { telemetry.End(span, err) }()
+
 	listRequest := mcp.ListToolsRequest{}
 	listResult, err := mcpClient.ListTools(ctx, listRequest)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list MCP tools: %w", err)
 	}
+	span.SetAttributes(telemetry.ToolCountKey.Int(len(listResult.Tools)))
 
 	langchainTools := make([]tools.Tool, 0, len(listResult.Tools))
 	for _, mcpTool := range listResult.Tools {
-		// Assuming no specific callback handler for now, pass nil
-		lcTool := NewLangchainMCPTool(mcpTool, mcpClient, nil)
+		lcTool := NewLangchainMCPTool(mcpTool, mcpClient, config.callbacks, opts...)
 		langchainTools = append(langchainTools, lcTool)
 	}
 
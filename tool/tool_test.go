@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -14,6 +15,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/schema"
+
+	"github.com/akihiro-fukuchi/langchaingo-mcp-adapters/metrics"
 )
 
 // --- Mocks ---
@@ -111,6 +114,25 @@ func TestProcessCallToolResult(t *testing.T) {
 			expectedText:  "Text part\nAnother text part",
 			expectedError: false,
 		},
+		{
+			name: "Success with structured content only",
+			result: &mcp.CallToolResult{
+				StructuredContent: map[string]any{"temperature": 21.5, "unit": "celsius"},
+				IsError:           false,
+			},
+			expectedText:  `{"temperature":21.5,"unit":"celsius"}`,
+			expectedError: false,
+		},
+		{
+			name: "Text content takes precedence over structured content",
+			result: &mcp.CallToolResult{
+				Content:           []mcp.Content{mcp.TextContent{Text: "21.5 celsius"}},
+				StructuredContent: map[string]any{"temperature": 21.5},
+				IsError:           false,
+			},
+			expectedText:  "21.5 celsius",
+			expectedError: false,
+		},
 		{
 			name: "Error result with text content",
 			result: &mcp.CallToolResult{
@@ -484,3 +506,61 @@ func TestLoadMCPTools_ListError(t *testing.T) {
 
 	Verify(mockClient, Once()).ListTools(Any[context.Context](), Any[mcp.ListToolsRequest]())
 }
+
+func TestLoadMCPTools_WithCallbacksHandler(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+	mockHandler := new(MockCallbackHandler)
+
+	listResult := &mcp.ListToolsResult{Tools: []mcp.Tool{{Name: "echo"}}}
+	When(mockClient.ListTools(Any[context.Context](), Any[mcp.ListToolsRequest]())).
+		ThenReturn(listResult, nil)
+	callResult := &mcp.CallToolResult{Content: []mcp.Content{mcp.NewTextContent("hello")}}
+	When(mockClient.CallTool(Any[context.Context](), Any[mcp.CallToolRequest]())).
+		ThenReturn(callResult, nil)
+
+	loadedTools, err := LoadMCPTools(context.Background(), mockClient, WithCallbacksHandler(mockHandler))
+	require.NoError(t, err)
+	require.Len(t, loadedTools, 1)
+
+	mockHandler.On("HandleToolStart", mock.Anything, `{"text": "hello"}`).Return()
+	mockHandler.On("HandleToolEnd", mock.Anything, "hello").Return()
+
+	output, err := loadedTools[0].Call(context.Background(), `{"text": "hello"}`)
+
+	require.NoError(t, err)
+	assert.Equal(t, "hello", output)
+	mockHandler.AssertExpectations(t)
+}
+
+type FakeRecorder struct {
+	calls []string
+}
+
+func (r *FakeRecorder) RecordToolCall(_ context.Context, serverName, toolName string, outcome metrics.Outcome, _ time.Duration) {
+	r.calls = append(r.calls, serverName+"/"+toolName+"/"+string(outcome))
+}
+func (r *FakeRecorder) RecordSessionOpened(context.Context, string) {}
+func (r *FakeRecorder) RecordSessionClosed(context.Context, string) {}
+func (r *FakeRecorder) RecordReconnect(context.Context, string)     {}
+
+func TestLangchainMCPTool_Call_Metrics(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+	recorder := &FakeRecorder{}
+	When(mockClient.CallTool(Any[context.Context](), Any[mcp.CallToolRequest]())).
+		ThenReturn(mcp.NewToolResultText("8"), nil).
+		ThenReturn(mcp.NewToolResultError("overflow"), nil).
+		ThenReturn(nil, errors.New("connection lost"))
+
+	lcTool := NewLangchainMCPTool(mcp.Tool{Name: "add"}, mockClient, nil, WithServerName("math"), WithMetrics(recorder))
+	for range 3 {
+		_, err := lcTool.Call(context.Background(), `{"a": 3, "b": 5}`)
+		require.NoError(t, err)
+	}
+	// Invalid input is rejected before a request is sent, so it is not recorded
+	_, err := lcTool.Call(context.Background(), "not json")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"math/add/success", "math/add/tool_error", "math/add/transport_error"}, recorder.calls)
+}
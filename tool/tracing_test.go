@@ -0,0 +1,178 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	. "github.com/ovechkin-dm/mockio/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTestTracerProvider() (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	return sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)), exporter
+}
+
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attributes := make(map[attribute.Key]attribute.Value, len(span.Attributes))
+	for _, kv := range span.Attributes {
+		attributes[kv.Key] = kv.Value
+	}
+	return attributes
+}
+
+func TestLangchainMCPTool_Call_Tracing(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+	tp, exporter := newTestTracerProvider()
+	requests := Captor[mcp.CallToolRequest]()
+	When(mockClient.CallTool(Any[context.Context](), requests.Capture())).
+		ThenReturn(&mcp.CallToolResult{Content: []mcp.Content{mcp.TextContent{Text: "8"}}}, nil)
+
+	lcTool := NewLangchainMCPTool(mcp.Tool{Name: "add"}, mockClient, nil,
+		WithServerName("math"),
+		WithTracerProvider(tp),
+		WithPropagator(propagation.TraceContext{}),
+	)
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "agent")
+	output, err := lcTool.Call(ctx, `{"a": 3, "b": 5}`)
+	parent.End()
+
+	require.NoError(t, err)
+	assert.Equal(t, "8", output)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, "tools/call add", span.Name)
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+	attributes := spanAttributes(span)
+	assert.Equal(t, "math", attributes["mcp.server.name"].AsString())
+	assert.Equal(t, "add", attributes["gen_ai.tool.name"].AsString())
+	assert.Equal(t, int64(16), attributes["mcp.request.arguments.size"].AsInt64())
+	assert.Equal(t, int64(1), attributes["mcp.response.result.size"].AsInt64())
+	assert.False(t, attributes["mcp.response.is_error"].AsBool())
+
+	// The trace context is sent in _meta, with the tool call span as parent
+	meta := requests.Last().Params.Meta
+	require.NotNil(t, meta)
+	traceparent, ok := meta.AdditionalFields["traceparent"].(string)
+	require.True(t, ok)
+	assert.Contains(t, traceparent, span.SpanContext.TraceID().String())
+	assert.Contains(t, traceparent, span.SpanContext.SpanID().String())
+}
+
+func TestLangchainMCPTool_Call_Tracing_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		result    *mcp.CallToolResult
+		err       error
+		isError   attribute.Value
+		errorText string
+	}{
+		{
+			name:      "Tool error",
+			result:    mcp.NewToolResultError("division by zero"),
+			isError:   attribute.BoolValue(true),
+			errorText: "tool divide execution failed: [redacted]",
+		},
+		{
+			name:      "Client error",
+			err:       errors.New("connection lost"),
+			errorText: "failed to call MCP tool divide: connection lost",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUp(t)
+			mockClient := Mock[MockMCPClient]()
+			tp, exporter := newTestTracerProvider()
+			When(mockClient.CallTool(Any[context.Context](), Any[mcp.CallToolRequest]())).ThenReturn(tt.result, tt.err)
+
+			lcTool := NewLangchainMCPTool(mcp.Tool{Name: "divide"}, mockClient, nil, WithTracerProvider(tp))
+			_, err := lcTool.Call(context.Background(), `{"a": 1, "b": 0}`)
+			require.NoError(t, err)
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, codes.Error, spans[0].Status.Code)
+			assert.Equal(t, tt.errorText, spans[0].Status.Description)
+			assert.Equal(t, tt.isError, spanAttributes(spans[0])["mcp.response.is_error"])
+		})
+	}
+}
+
+func TestLangchainMCPTool_Call_Tracing_RedactsPayloads(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+	When(mockClient.CallTool(Any[context.Context](), Any[mcp.CallToolRequest]())).
+		ThenReturn(mcp.NewToolResultError("account 4242 is locked"), nil)
+	mcpTool := mcp.Tool{Name: "lookup", InputSchema: mcp.ToolInputSchema{Properties: map[string]any{"a": map[string]any{}, "b": map[string]any{}}}}
+
+	for _, logPayloads := range []bool{false, true} {
+		tp, exporter := newTestTracerProvider()
+		opts := []Option{WithTracerProvider(tp)}
+		if logPayloads {
+			opts = append(opts, WithPayloadLogging())
+		}
+		lcTool := NewLangchainMCPTool(mcpTool, mockClient, nil, opts...)
+		_, err := lcTool.Call(context.Background(), "password=hunter2")
+		require.NoError(t, err)
+		_, err = lcTool.Call(context.Background(), `{"a": 1}`)
+		require.NoError(t, err)
+		_, err = lcTool.CallStructured(context.Background(), map[string]any{"a": 1})
+		require.Error(t, err)
+
+		var recorded []string
+		for _, span := range exporter.GetSpans() {
+			recorded = append(recorded, span.Status.Description)
+			for _, event := range span.Events {
+				for _, kv := range event.Attributes {
+					recorded = append(recorded, kv.Value.Emit())
+				}
+			}
+		}
+		require.Len(t, exporter.GetSpans(), 3)
+		joined := strings.Join(recorded, "\n")
+		if logPayloads {
+			assert.Contains(t, joined, "hunter2")
+			assert.Contains(t, joined, "4242")
+		} else {
+			assert.NotContains(t, joined, "hunter2", "The tool input should not be recorded on spans")
+			assert.NotContains(t, joined, "4242", "The tool output should not be recorded on spans")
+			assert.Contains(t, joined, "tool lookup execution failed: [redacted]")
+		}
+	}
+}
+
+func TestLoadMCPTools_Tracing(t *testing.T) {
+	SetUp(t)
+	mockClient := Mock[MockMCPClient]()
+	tp, exporter := newTestTracerProvider()
+	When(mockClient.ListTools(Any[context.Context](), Any[mcp.ListToolsRequest]())).
+		ThenReturn(&mcp.ListToolsResult{Tools: []mcp.Tool{{Name: "add"}, {Name: "multiply"}}}, nil)
+
+	loadedTools, err := LoadMCPTools(context.Background(), mockClient, WithServerName("math"), WithTracerProvider(tp))
+	require.NoError(t, err)
+	require.Len(t, loadedTools, 2)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "tools/list", spans[0].Name)
+	attributes := spanAttributes(spans[0])
+	assert.Equal(t, "math", attributes["mcp.server.name"].AsString())
+	assert.Equal(t, int64(2), attributes["mcp.tools.count"].AsInt64())
+}